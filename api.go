@@ -1,13 +1,38 @@
 package twitchgo
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/kesuaheli/twitchgo/oauth"
 )
 
+// maxIDsPerRequest is the maximum number of IDs most endpoints accept in a single request.
+const maxIDsPerRequest = 100
+
+// chunk splits ids into batches of at most size elements.
+func chunk(ids []string, size int) [][]string {
+	var batches [][]string
+	for len(ids) > size {
+		batches = append(batches, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
+
 // pagination contains information used to page through the list of results. The object is empty if
 // there are no more pages left to page through.
 type pagination struct {
@@ -16,53 +41,353 @@ type pagination struct {
 	Cursor string `json:"cursor"`
 }
 
-func (s *Session) requestHelper(method, endpoint string, queryParams map[string][]string, body io.Reader, result any) error {
-	req, err := s.buildRequest(method, endpoint, queryParams, body)
+// ErrMissingScope is returned by API calls, when the token is missing the scope required by the
+// endpoint.
+var ErrMissingScope = errors.New("missing scope")
+
+// APIError is returned by API calls, when the Twitch API responds with a status code other than
+// 2xx. Use [errors.As] to get it from the returned error:
+//
+//	var apiErr *twitchgo.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+//		// ...
+//	}
+type APIError struct {
+	// The HTTP status code, e.g. 404.
+	StatusCode int
+	// The HTTP status, e.g. "404 Not Found".
+	Status string
+	// The raw body of the response.
+	Body string
+
+	// The error from the error body Twitch sent, e.g. "Not Found". It is empty, if the body was not
+	// a Twitch error.
+	TwitchError string
+	// The message from the error body Twitch sent, describing what went wrong. It is empty, if the
+	// body was not a Twitch error.
+	TwitchMessage string
+
+	// RetryAfter is the duration from the Retry-After header. It is zero, if the header was not
+	// set.
+	RetryAfter time.Duration
+	// The headers of the response, e.g. the Twitch-Trace-Id useful when contacting Twitch support.
+	Header http.Header
+}
+
+// newAPIError creates an APIError from resp and its already read body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Header:     resp.Header,
+	}
+
+	var twitchErr struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &twitchErr) == nil {
+		e.TwitchError = twitchErr.Error
+		e.TwitchMessage = twitchErr.Message
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.TwitchMessage != "" {
+		return fmt.Sprintf("expected a 2xx status code, but got '%s': %s", e.Status, e.TwitchMessage)
+	}
+	return fmt.Sprintf("expected a 2xx status code, but got '%s': %s", e.Status, e.Body)
+}
+
+// statusCode returns the HTTP status code of err, if it is (or wraps) an *APIError. It returns 0
+// if err is nil and -1 for any other error.
+func statusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return -1
+}
+
+// rateLimit holds the state of the Twitch API rate limit as reported by the headers of the latest
+// response.
+type rateLimit struct {
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// update updates the rate limit from the given response headers. Missing headers are ignored.
+func (rl *rateLimit) update(header http.Header) {
+	limit, errLimit := strconv.Atoi(header.Get("Ratelimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(header.Get("Ratelimit-Remaining"))
+	reset, errReset := strconv.ParseInt(header.Get("Ratelimit-Reset"), 10, 64)
+	if errLimit != nil || errRemaining != nil || errReset != nil {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limit = limit
+	rl.remaining = remaining
+	rl.reset = time.Unix(reset, 0)
+}
+
+// wait blocks until the rate limit is reset, if there are no requests remaining. It returns early
+// with the error of ctx, if ctx is done before.
+func (rl *rateLimit) wait(ctx context.Context) error {
+	rl.mu.Lock()
+	var d time.Duration
+	if rl.remaining <= 0 {
+		d = time.Until(rl.reset)
+	}
+	rl.mu.Unlock()
+
+	return sleepContext(ctx, d)
+}
+
+// sleepContext pauses for at least the duration d or until ctx is done. In the latter case the
+// error of ctx is returned.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RateLimit returns the current state of the Twitch API rate limit, as reported by the latest API
+// response. limit is the number of requests allowed per minute, remaining the number of requests
+// left and reset the time the limit is reset to its maximum.
+//
+// API calls automatically wait for the reset, if there are no requests remaining.
+func (s *Session) RateLimit() (limit, remaining int, reset time.Time) {
+	s.rateLimit.mu.Lock()
+	defer s.rateLimit.mu.Unlock()
+	return s.rateLimit.limit, s.rateLimit.remaining, s.rateLimit.reset
+}
+
+// Do sends an authorized request to an endpoint of the Twitch API, which is not covered by this
+// package, e.g. Do("GET", "/chat/color", url.Values{"user_id": {"123"}}, nil, &result). The
+// endpoint is relative to "https://api.twitch.tv/helix". If result is not nil, the JSON response is
+// decoded into it.
+//
+// Do returns the same errors as all other API calls, i.e. an error if the response status code is
+// not 2xx. The request is retried as configured by [Session.SetAPIRetries].
+func (s *Session) Do(method, endpoint string, query url.Values, body io.Reader, result any) error {
+	return s.DoContext(context.Background(), method, endpoint, query, body, result)
+}
+
+// DoContext is like [Session.Do] but uses ctx for the API requests.
+func (s *Session) DoContext(ctx context.Context, method, endpoint string, query url.Values, body io.Reader, result any) error {
+	return s.requestHelper(ctx, method, endpoint, query, body, result)
+}
+
+// ValidateToken validates the current access token. Twitch requires applications to validate user
+// access tokens on startup and hourly afterwards.
+//
+// Returns [oauth.ErrInvalidToken], if the token is no longer valid.
+func (s *Session) ValidateToken() (*oauth.ValidateResult, error) {
+	t, err := s.oauth.GenerateToken()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("generate token: %v", err)
 	}
+	return s.oauth.Validate(t)
+}
 
+// RevokeToken revokes the current access token, e.g. when a user logs out or on shutdown.
+func (s *Session) RevokeToken() error {
 	t, err := s.oauth.GenerateToken()
 	if err != nil {
 		return fmt.Errorf("generate token: %v", err)
 	}
+	return s.oauth.Revoke(t)
+}
+
+// requireScope checks whether the current token was granted at least one of the given scopes.
+// Otherwise an error wrapping [ErrMissingScope] is returned.
+func (s *Session) requireScope(scopes ...string) error {
+	if _, err := s.oauth.GenerateToken(); err != nil {
+		return fmt.Errorf("generate token: %v", err)
+	}
+	for _, scope := range scopes {
+		if s.oauth.HasScope(scope) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrMissingScope, strings.Join(scopes, " or "))
+}
+
+func (s *Session) requestHelper(ctx context.Context, method, endpoint string, queryParams map[string][]string, body io.Reader, result any) error {
+	var bodyData []byte
+	if body != nil {
+		var err error
+		bodyData, err = io.ReadAll(body)
+		if err != nil {
+			return fmt.Errorf("read request body: %v", err)
+		}
+	}
+
+	_, respData, err := s.doRequestWithAuthRetry(ctx, method, endpoint, queryParams, bodyData)
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respData, result)
+}
+
+// DoWithResponse does an authorized request to an endpoint of the Twitch API, e.g. "/users", and
+// returns the response with its body. The body of the response is already read and closed. This
+// is useful to call endpoints not covered by this package or to inspect the response headers,
+// like the rate limit or the Twitch-Trace-Id.
+//
+// The request is retried like all other API calls, see [Session.SetAPIRetries]. If the status code
+// is not 2xx, the response and its body are returned together with an [APIError].
+func (s *Session) DoWithResponse(method, endpoint string, query url.Values, body io.Reader) (*http.Response, []byte, error) {
+	return s.DoWithResponseContext(context.Background(), method, endpoint, query, body)
+}
+
+// DoWithResponseContext is like [Session.DoWithResponse] but uses ctx for the API requests.
+func (s *Session) DoWithResponseContext(ctx context.Context, method, endpoint string, query url.Values, body io.Reader) (*http.Response, []byte, error) {
+	var bodyData []byte
+	if body != nil {
+		var err error
+		bodyData, err = io.ReadAll(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read request body: %v", err)
+		}
+	}
+	return s.doRequestWithAuthRetry(ctx, method, endpoint, query, bodyData)
+}
+
+// doRequestWithAuthRetry does the request like [Session.doRequestWithRetry] and retries it once
+// with a new token, if it failed as unauthorized.
+func (s *Session) doRequestWithAuthRetry(ctx context.Context, method, endpoint string, queryParams map[string][]string, body []byte) (*http.Response, []byte, error) {
+	resp, respData, err := s.doRequestWithRetry(ctx, method, endpoint, queryParams, body)
+	if statusCode(err) == http.StatusUnauthorized {
+		// The cached token might be revoked or expired earlier than expected. Retry once with a
+		// freshly generated token.
+		s.oauth.Invalidate()
+		resp, respData, err = s.doRequestWithRetry(ctx, method, endpoint, queryParams, body)
+	}
+	return resp, respData, err
+}
+
+// doRequestWithRetry does the request and retries it with exponential backoff, as long as it
+// fails with a transient error. See [Session.SetAPIRetries].
+func (s *Session) doRequestWithRetry(ctx context.Context, method, endpoint string, queryParams map[string][]string, body []byte) (*http.Response, []byte, error) {
+	delay := s.apiRetryDelay
+	for attempt := 1; ; attempt++ {
+		resp, respData, err := s.doRequest(ctx, method, endpoint, queryParams, body)
+		var apiErr *APIError
+		if attempt >= s.apiMaxAttempts || !errors.As(err, &apiErr) || !isTransientStatus(apiErr.StatusCode) {
+			return resp, respData, err
+		}
+
+		wait := delay
+		if apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		if err = sleepContext(ctx, wait); err != nil {
+			return nil, nil, err
+		}
+		delay *= 2
+	}
+}
+
+// isTransientStatus reports whether a request failing with statusCode is worth a retry.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or
+// a HTTP date. It returns 0 if the value is empty or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// doRequest does a single authorized request and returns the response and its body. If the status
+// code is not 2xx, the response and body are returned together with an [APIError].
+func (s *Session) doRequest(ctx context.Context, method, endpoint string, queryParams map[string][]string, body []byte) (*http.Response, []byte, error) {
+	req, err := s.buildRequest(ctx, method, endpoint, queryParams, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := s.oauth.GenerateToken()
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate token: %v", err)
+	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+t)
 	req.Header.Set("Client-Id", s.clientID)
 
+	if err = s.rateLimit.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	s.stats.apiCalls.Add(1)
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		s.stats.apiErrors.Add(1)
+		return nil, nil, err
 	}
+	defer resp.Body.Close()
+	s.rateLimit.update(resp.Header)
 
 	respData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read response body: %v", err)
+		return resp, nil, fmt.Errorf("read response body: %v", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("expected a 2xx status code, but got '%s': %s", resp.Status, respData)
+		s.stats.apiErrors.Add(1)
+		return resp, respData, newAPIError(resp, respData)
 	}
-
-	if result == nil {
-		return nil
-	}
-	return json.Unmarshal(respData, result)
+	return resp, respData, nil
 }
 
-func (s *Session) buildRequest(method, endpoint string, queryParams map[string][]string, body io.Reader) (req *http.Request, err error) {
-	req, err = http.NewRequest(method, baseURL+endpoint, body)
+func (s *Session) buildRequest(ctx context.Context, method, endpoint string, queryParams map[string][]string, body io.Reader) (req *http.Request, err error) {
+	req, err = http.NewRequestWithContext(ctx, method, baseURL+endpoint, body)
 	if err != nil {
 		return
 	}
 
-	var rawQueries []string
-	for k, v := range queryParams {
-		for _, v := range v {
-			rawQueries = append(rawQueries, fmt.Sprintf("%s=%s", k, v))
-		}
-	}
-	req.URL.RawQuery = strings.Join(rawQueries, "&")
+	req.URL.RawQuery = url.Values(queryParams).Encode()
 	return
 }
@@ -0,0 +1,149 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type rawCommercialData struct {
+	// A list that contains a single object with the status of the commercial.
+	Data []*CommercialResult `json:"data"`
+}
+
+// CommercialResult is the status of a commercial started by [Session.StartCommercial].
+type CommercialResult struct {
+	// The length of the commercial you requested. If you request a commercial that’s longer than
+	// 180 seconds, the API uses 180 seconds.
+	Length int `json:"length"`
+	// A message that indicates whether Twitch was able to serve an ad.
+	Message string `json:"message"`
+	// The number of seconds you must wait before running another commercial.
+	RetryAfter int `json:"retry_after"`
+}
+
+// StartCommercial starts a commercial on the channel of the broadcaster. length is the length of
+// the commercial in seconds, between 30 and 180. Only partners and affiliates may run commercials
+// and they must be streaming live. The current session has to have the "channel:edit:commercial"
+// permission and has to be the broadcaster.
+func (s *Session) StartCommercial(broadcasterID string, length int) (*CommercialResult, error) {
+	return s.StartCommercialContext(context.Background(), broadcasterID, length)
+}
+
+// StartCommercialContext is like [Session.StartCommercial] but uses ctx for the API requests.
+func (s *Session) StartCommercialContext(ctx context.Context, broadcasterID string, length int) (*CommercialResult, error) {
+	if err := s.requireScope("channel:edit:commercial"); err != nil {
+		return nil, err
+	}
+	if length < 30 || length > 180 {
+		return nil, fmt.Errorf("start commercial: length %d out of range [30, 180]", length)
+	}
+
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		BroadcasterID string `json:"broadcaster_id"`
+		Length        int    `json:"length"`
+	}{broadcasterID, length})
+	if err != nil {
+		return nil, fmt.Errorf("encode commercial: %v", err)
+	}
+
+	var commercialData rawCommercialData
+	err = s.requestHelper(ctx, http.MethodPost, "/channels/commercial", nil, body, &commercialData)
+	if err != nil {
+		return nil, fmt.Errorf("start commercial: %w", err)
+	}
+	if len(commercialData.Data) == 0 {
+		return nil, fmt.Errorf("start commercial: empty response")
+	}
+	return commercialData.Data[0], nil
+}
+
+// AdSchedule is the ad schedule of a broadcaster’s channel.
+type AdSchedule struct {
+	// The number of snoozes available for the broadcaster.
+	SnoozeCount int
+	// The UTC timestamp when the broadcaster will gain an additional snooze. Is the zero time if
+	// unknown.
+	SnoozeRefreshAt time.Time
+	// The UTC timestamp of the broadcaster’s next scheduled ad. Is the zero time if the channel has
+	// no ad scheduled or is not live.
+	NextAdAt time.Time
+	// The length of the next scheduled ad break.
+	Duration time.Duration
+	// The UTC timestamp of the broadcaster’s last ad break. Is the zero time if the channel has not
+	// run an ad or is not live.
+	LastAdAt time.Time
+	// The amount of pre-roll free time remaining for the channel.
+	PrerollFreeTime time.Duration
+}
+
+type rawAdScheduleData struct {
+	Data []struct {
+		SnoozeCount int `json:"snooze_count"`
+		// empty, if unknown
+		SnoozeRefreshAt string `json:"snooze_refresh_at"`
+		// empty, if no ad is scheduled
+		NextAdAt string `json:"next_ad_at"`
+		// in seconds
+		Duration int `json:"duration"`
+		// empty, if no ad was run
+		LastAdAt string `json:"last_ad_at"`
+		// in seconds
+		PrerollFreeTime int `json:"preroll_free_time"`
+	} `json:"data"`
+}
+
+// GetAdSchedule gets the ad schedule of the broadcaster’s channel. The current session has to
+// have the "channel:read:ads" permission and has to be the broadcaster.
+func (s *Session) GetAdSchedule(broadcasterID string) (*AdSchedule, error) {
+	return s.GetAdScheduleContext(context.Background(), broadcasterID)
+}
+
+// GetAdScheduleContext is like [Session.GetAdSchedule] but uses ctx for the API requests.
+func (s *Session) GetAdScheduleContext(ctx context.Context, broadcasterID string) (*AdSchedule, error) {
+	if err := s.requireScope("channel:read:ads"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+
+	var adScheduleData rawAdScheduleData
+	err := s.requestHelper(ctx, http.MethodGet, "/channels/ads", queryParams, nil, &adScheduleData)
+	if err != nil {
+		return nil, fmt.Errorf("get ad schedule: %w", err)
+	}
+	if len(adScheduleData.Data) == 0 {
+		return nil, fmt.Errorf("get ad schedule: empty response")
+	}
+
+	raw := adScheduleData.Data[0]
+	schedule := &AdSchedule{
+		SnoozeCount:     raw.SnoozeCount,
+		Duration:        time.Duration(raw.Duration) * time.Second,
+		PrerollFreeTime: time.Duration(raw.PrerollFreeTime) * time.Second,
+	}
+	for _, t := range []struct {
+		raw string
+		dst *time.Time
+	}{
+		{raw.SnoozeRefreshAt, &schedule.SnoozeRefreshAt},
+		{raw.NextAdAt, &schedule.NextAdAt},
+		{raw.LastAdAt, &schedule.LastAdAt},
+	} {
+		if t.raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, t.raw)
+		if err != nil {
+			return nil, fmt.Errorf("get ad schedule: parse timestamp: %v", err)
+		}
+		*t.dst = parsed
+	}
+	return schedule, nil
+}
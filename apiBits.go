@@ -0,0 +1,167 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type rawCheermoteData struct {
+	// The list of cheermotes.
+	Data []*Cheermote `json:"data"`
+}
+
+// Cheermote is an emote users can cheer Bits with, e.g. "Cheer100".
+type Cheermote struct {
+	// The name portion of the Cheermote string that you use in chat to cheer Bits. The full
+	// Cheermote string is the concatenation of {prefix} + {number of Bits}. For example, if the
+	// prefix is "Cheer" and you want to cheer 100 Bits, the full Cheermote string is "Cheer100".
+	Prefix string `json:"prefix"`
+	// A list of tier levels that the Cheermote supports. Each tier identifies the range of Bits
+	// that you can cheer at that tier level and an image that graphically identifies the tier
+	// level.
+	Tiers []*CheermoteTier `json:"tiers"`
+	// The type of Cheermote. Possible values are:
+	//	"global_first_party"  // A Twitch-defined Cheermote that is shown in the Bits card.
+	//	"global_third_party"  // A Twitch-defined Cheermote that is not shown in the Bits card.
+	//	"channel_custom"      // A broadcaster-defined Cheermote.
+	//	"display_only"        // Do not use; for internal use only.
+	//	"sponsored"           // A sponsor-defined Cheermote.
+	Type string `json:"type"`
+	// The order that the Cheermotes are shown in the Bits card. The numbers may not be
+	// consecutive.
+	Order int `json:"order"`
+	// The date and time, in UTC, when this Cheermote was last updated.
+	LastUpdated time.Time `json:"last_updated"`
+	// A Boolean value that indicates whether this Cheermote provides a charitable contribution
+	// match during charity campaigns.
+	IsCharitable bool `json:"is_charitable"`
+}
+
+// CheermoteTier is a tier level of a [Cheermote].
+type CheermoteTier struct {
+	// The minimum number of Bits that you must cheer at this tier level.
+	MinBits int `json:"min_bits"`
+	// The tier level. Possible tiers are: "1", "100", "500", "1000", "5000", "10000", "100000".
+	ID string `json:"id"`
+	// The hex code of the color associated with this tier level, e.g. "#979797".
+	Color string `json:"color"`
+	// The animated and static image sets for the Cheermote.
+	Images CheermoteImages `json:"images"`
+	// A Boolean value that determines whether users can cheer at this tier level.
+	CanCheer bool `json:"can_cheer"`
+	// A Boolean value that determines whether this tier level is shown in the Bits card.
+	ShowInBitsCard bool `json:"show_in_bits_card"`
+}
+
+// CheermoteImages are the images of a [CheermoteTier] for the dark and light theme.
+type CheermoteImages struct {
+	Dark  CheermoteImageSet `json:"dark"`
+	Light CheermoteImageSet `json:"light"`
+}
+
+// CheermoteImageSet are the animated and static images of a [CheermoteTier]. The maps are keyed by
+// the size of the image, which is one of "1", "1.5", "2", "3" or "4".
+type CheermoteImageSet struct {
+	Animated map[string]string `json:"animated"`
+	Static   map[string]string `json:"static"`
+}
+
+// GetCheermotes gets the list of Cheermotes that users can use to cheer Bits in the channel of the
+// given broadcaster. If broadcasterID is empty, only the global Cheermotes are returned.
+func (s *Session) GetCheermotes(broadcasterID string) ([]*Cheermote, error) {
+	return s.GetCheermotesContext(context.Background(), broadcasterID)
+}
+
+// GetCheermotesContext is like [Session.GetCheermotes] but uses ctx for the API requests.
+func (s *Session) GetCheermotesContext(ctx context.Context, broadcasterID string) ([]*Cheermote, error) {
+	var queryParams map[string][]string
+	if broadcasterID != "" {
+		queryParams = map[string][]string{
+			"broadcaster_id": {broadcasterID},
+		}
+	}
+
+	var cheermoteData rawCheermoteData
+	err := s.requestHelper(ctx, http.MethodGet, "/bits/cheermotes", queryParams, nil, &cheermoteData)
+	if err != nil {
+		return nil, fmt.Errorf("get cheermotes: %w", err)
+	}
+	return cheermoteData.Data, nil
+}
+
+type rawBitsLeaderboardData struct {
+	// The list of leaders on the leaderboard.
+	Data []*BitsLeader `json:"data"`
+}
+
+// BitsLeader is a user on the Bits leaderboard of a broadcaster.
+type BitsLeader struct {
+	// An ID that identifies a user on the leaderboard.
+	UserID string `json:"user_id"`
+	// The user’s login name.
+	UserLogin string `json:"user_login"`
+	// The user’s display name.
+	UserName string `json:"user_name"`
+	// The user’s position on the leaderboard.
+	Rank int `json:"rank"`
+	// The number of Bits the user has cheered.
+	Score int `json:"score"`
+}
+
+// bitsLeaderboardPeriods are the periods accepted by [Session.GetBitsLeaderboard].
+var bitsLeaderboardPeriods = map[string]bool{
+	"day":   true,
+	"week":  true,
+	"month": true,
+	"year":  true,
+	"all":   true,
+}
+
+// GetBitsLeaderboard gets the users who cheered the most Bits in the channel of the current
+// session, ordered by their rank. count is the number of leaders to return, between 1 and 100.
+//
+// period is the time period over which the Bits are aggregated, one of "day", "week", "month",
+// "year" or "all". The period begins at startedAt, which is ignored for "all" or if it is the zero
+// time. Days start at 00:00:00 PST, weeks on Monday, months on the first day of the month and
+// years on January 1.
+//
+// If userID is not empty, the leaderboard contains the given user and the users ranked around
+// them instead of the top users. The current session has to have the "bits:read" permission.
+func (s *Session) GetBitsLeaderboard(count int, period string, startedAt time.Time, userID string) ([]*BitsLeader, error) {
+	return s.GetBitsLeaderboardContext(context.Background(), count, period, startedAt, userID)
+}
+
+// GetBitsLeaderboardContext is like [Session.GetBitsLeaderboard] but uses ctx for the API
+// requests.
+func (s *Session) GetBitsLeaderboardContext(ctx context.Context, count int, period string, startedAt time.Time, userID string) ([]*BitsLeader, error) {
+	if err := s.requireScope("bits:read"); err != nil {
+		return nil, err
+	}
+	if !bitsLeaderboardPeriods[period] {
+		return nil, fmt.Errorf("get bits leaderboard: invalid period '%s'", period)
+	}
+	if count < 1 || count > 100 {
+		return nil, fmt.Errorf("get bits leaderboard: count %d out of range [1, 100]", count)
+	}
+
+	queryParams := map[string][]string{
+		"count":  {strconv.Itoa(count)},
+		"period": {period},
+	}
+	if !startedAt.IsZero() && period != "all" {
+		queryParams["started_at"] = []string{startedAt.Format(time.RFC3339)}
+	}
+	if userID != "" {
+		queryParams["user_id"] = []string{userID}
+	}
+
+	var leaderboardData rawBitsLeaderboardData
+	err := s.requestHelper(ctx, http.MethodGet, "/bits/leaderboard", queryParams, nil, &leaderboardData)
+	if err != nil {
+		return nil, fmt.Errorf("get bits leaderboard: %w", err)
+	}
+	return leaderboardData.Data, nil
+}
@@ -0,0 +1,457 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+type rawCustomRewardData struct {
+	// The list of custom rewards.
+	Data []*CustomReward `json:"data"`
+}
+
+// CustomReward is a custom channel point reward of a broadcaster.
+type CustomReward struct {
+	// The ID that uniquely identifies this custom reward.
+	ID string `json:"id"`
+	// The ID that uniquely identifies the broadcaster.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+
+	// The title of the reward.
+	Title string `json:"title"`
+	// The prompt shown to the viewer when they redeem the reward if user input is required.
+	Prompt string `json:"prompt"`
+	// The cost of the reward in Channel Points.
+	Cost int `json:"cost"`
+	// The custom images for the reward. Is nil if the broadcaster didn’t upload images.
+	Image *CustomRewardImage `json:"image"`
+	// The default images for the reward.
+	DefaultImage CustomRewardImage `json:"default_image"`
+	// The background color to use for the reward. The color is in Hex format, e.g. "#00E5CB".
+	BackgroundColor string `json:"background_color"`
+
+	// A Boolean value that determines whether the reward is enabled. Viewers see only enabled
+	// rewards.
+	IsEnabled bool `json:"is_enabled"`
+	// A Boolean value that determines whether the user must enter information when redeeming the
+	// reward.
+	IsUserInputRequired bool `json:"is_user_input_required"`
+	// The settings used to determine whether to apply a maximum to the number of redemptions
+	// allowed per live stream.
+	MaxPerStreamSetting struct {
+		// A Boolean value that determines whether the reward applies a limit on the number of
+		// redemptions allowed per live stream.
+		IsEnabled bool `json:"is_enabled"`
+		// The maximum number of redemptions allowed per live stream.
+		MaxPerStream int `json:"max_per_stream"`
+	} `json:"max_per_stream_setting"`
+	// The settings used to determine whether to apply a maximum to the number of redemptions
+	// allowed per user per live stream.
+	MaxPerUserPerStreamSetting struct {
+		// A Boolean value that determines whether the reward applies a limit on the number of
+		// redemptions allowed per user per live stream.
+		IsEnabled bool `json:"is_enabled"`
+		// The maximum number of redemptions allowed per user per live stream.
+		MaxPerUserPerStream int `json:"max_per_user_per_stream"`
+	} `json:"max_per_user_per_stream_setting"`
+	// The settings used to determine whether to apply a cooldown period between redemptions and
+	// the length of the cooldown.
+	GlobalCooldownSetting struct {
+		// A Boolean value that determines whether to apply a cooldown period.
+		IsEnabled bool `json:"is_enabled"`
+		// The cooldown period, in seconds.
+		GlobalCooldownSeconds int `json:"global_cooldown_seconds"`
+	} `json:"global_cooldown_setting"`
+
+	// A Boolean value that determines whether the reward is currently paused. Viewers can’t redeem
+	// paused rewards.
+	IsPaused bool `json:"is_paused"`
+	// A Boolean value that determines whether the reward is currently in stock. Viewers can’t
+	// redeem out of stock rewards.
+	IsInStock bool `json:"is_in_stock"`
+	// A Boolean value that determines whether redemptions should be set to FULFILLED status
+	// immediately when a reward is redeemed. If false, status is UNFULFILLED and follows the
+	// normal request queue process.
+	ShouldRedemptionsSkipRequestQueue bool `json:"should_redemptions_skip_request_queue"`
+	// The number of redemptions redeemed during the current live stream. The number counts against
+	// the MaxPerStreamSetting limit. Is nil if the broadcaster’s stream isn’t live or
+	// MaxPerStreamSetting isn’t enabled.
+	RedemptionsRedeemedCurrentStream *int `json:"redemptions_redeemed_current_stream"`
+	// The timestamp of when the cooldown period expires. Is nil if the reward isn’t in a cooldown
+	// state.
+	CooldownExpiresAt *time.Time `json:"cooldown_expires_at"`
+}
+
+// CustomRewardImage are the URLs of the images of a [CustomReward] in all three sizes.
+type CustomRewardImage struct {
+	// The URL to a small version of the image.
+	URL1x string `json:"url_1x"`
+	// The URL to a medium version of the image.
+	URL2x string `json:"url_2x"`
+	// The URL to a large version of the image.
+	URL4x string `json:"url_4x"`
+}
+
+// CustomRewardParams are the settings of a custom reward used by [Session.CreateCustomReward] and
+// [Session.UpdateCustomReward]. Title and Cost are required when creating a reward. When updating
+// a reward, only the fields that are set are changed, so the other fields are pointers.
+type CustomRewardParams struct {
+	// The custom reward’s title. The title may contain a maximum of 45 characters and it must be
+	// unique amongst all of the broadcaster’s custom rewards.
+	Title string `json:"title,omitempty"`
+	// The cost of the reward, in Channel Points. The minimum is 1 point.
+	Cost int `json:"cost,omitempty"`
+	// The prompt shown to the viewer when they redeem the reward. The prompt is limited to a
+	// maximum of 200 characters.
+	Prompt *string `json:"prompt,omitempty"`
+	// The background color to use for the reward. Specify the color using Hex format, e.g.
+	// "#9147FF".
+	BackgroundColor string `json:"background_color,omitempty"`
+
+	// A Boolean value that determines whether the reward is enabled. Viewers see only enabled
+	// rewards. Rewards are enabled by default.
+	IsEnabled *bool `json:"is_enabled,omitempty"`
+	// A Boolean value that determines whether the user needs to enter information when redeeming
+	// the reward.
+	IsUserInputRequired *bool `json:"is_user_input_required,omitempty"`
+	// A Boolean value that determines whether to limit the maximum number of redemptions allowed
+	// per live stream.
+	IsMaxPerStreamEnabled *bool `json:"is_max_per_stream_enabled,omitempty"`
+	// The maximum number of redemptions allowed per live stream. The minimum value is 1.
+	MaxPerStream int `json:"max_per_stream,omitempty"`
+	// A Boolean value that determines whether to limit the maximum number of redemptions allowed
+	// per user per stream.
+	IsMaxPerUserPerStreamEnabled *bool `json:"is_max_per_user_per_stream_enabled,omitempty"`
+	// The maximum number of redemptions allowed per user per stream. The minimum value is 1.
+	MaxPerUserPerStream int `json:"max_per_user_per_stream,omitempty"`
+	// A Boolean value that determines whether to apply a cooldown period between redemptions.
+	IsGlobalCooldownEnabled *bool `json:"is_global_cooldown_enabled,omitempty"`
+	// The cooldown period, in seconds. The minimum value is 1; however, the minimum value is 60
+	// for it to be shown in the Twitch UX.
+	GlobalCooldownSeconds int `json:"global_cooldown_seconds,omitempty"`
+	// A Boolean value that determines whether the reward is paused. Viewers can’t redeem paused
+	// rewards.
+	IsPaused *bool `json:"is_paused,omitempty"`
+	// A Boolean value that determines whether redemptions should be set to FULFILLED status
+	// immediately when a reward is redeemed.
+	ShouldRedemptionsSkipRequestQueue *bool `json:"should_redemptions_skip_request_queue,omitempty"`
+}
+
+// CreateCustomReward creates a custom channel point reward in the channel of the given broadcaster
+// and returns it. The current session has to have the "channel:manage:redemptions" permission and
+// has to be the broadcaster.
+func (s *Session) CreateCustomReward(broadcasterID string, params CustomRewardParams) (*CustomReward, error) {
+	return s.CreateCustomRewardContext(context.Background(), broadcasterID, params)
+}
+
+// CreateCustomRewardContext is like [Session.CreateCustomReward] but uses ctx for the API
+// requests.
+func (s *Session) CreateCustomRewardContext(ctx context.Context, broadcasterID string, params CustomRewardParams) (*CustomReward, error) {
+	if err := s.requireScope("channel:manage:redemptions"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+	reward, err := s.sendCustomReward(ctx, http.MethodPost, queryParams, params)
+	if err != nil {
+		return nil, fmt.Errorf("create custom reward: %w", err)
+	}
+	return reward, nil
+}
+
+// GetCustomRewards gets all custom channel point rewards of the given broadcaster. If
+// onlyManageable is true, only the rewards which were created by the same client ID are returned.
+// The current session has to have the "channel:read:redemptions" or "channel:manage:redemptions"
+// permission and has to be the broadcaster.
+func (s *Session) GetCustomRewards(broadcasterID string, onlyManageable bool) ([]*CustomReward, error) {
+	return s.GetCustomRewardsContext(context.Background(), broadcasterID, onlyManageable)
+}
+
+// GetCustomRewardsContext is like [Session.GetCustomRewards] but uses ctx for the API requests.
+func (s *Session) GetCustomRewardsContext(ctx context.Context, broadcasterID string, onlyManageable bool) ([]*CustomReward, error) {
+	if err := s.requireScope("channel:read:redemptions", "channel:manage:redemptions"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+	if onlyManageable {
+		queryParams["only_manageable_rewards"] = []string{"true"}
+	}
+
+	var rewardData rawCustomRewardData
+	err := s.requestHelper(ctx, http.MethodGet, "/channel_points/custom_rewards", queryParams, nil, &rewardData)
+	if err != nil {
+		return nil, fmt.Errorf("get custom rewards: %w", err)
+	}
+	return rewardData.Data, nil
+}
+
+// UpdateCustomReward updates the given custom reward and returns it. Only the fields set in
+// params are changed. The current session has to have the "channel:manage:redemptions"
+// permission and has to be the broadcaster. Only rewards created by the same client ID can be
+// updated.
+func (s *Session) UpdateCustomReward(broadcasterID, rewardID string, params CustomRewardParams) (*CustomReward, error) {
+	return s.UpdateCustomRewardContext(context.Background(), broadcasterID, rewardID, params)
+}
+
+// UpdateCustomRewardContext is like [Session.UpdateCustomReward] but uses ctx for the API
+// requests.
+func (s *Session) UpdateCustomRewardContext(ctx context.Context, broadcasterID, rewardID string, params CustomRewardParams) (*CustomReward, error) {
+	if err := s.requireScope("channel:manage:redemptions"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"id":             {rewardID},
+	}
+	reward, err := s.sendCustomReward(ctx, http.MethodPatch, queryParams, params)
+	if err != nil {
+		return nil, fmt.Errorf("update custom reward: %w", err)
+	}
+	return reward, nil
+}
+
+// DeleteCustomReward deletes the given custom reward. The current session has to have the
+// "channel:manage:redemptions" permission and has to be the broadcaster. Only rewards created by
+// the same client ID can be deleted. Unfulfilled redemptions of the reward are refunded.
+func (s *Session) DeleteCustomReward(broadcasterID, rewardID string) error {
+	return s.DeleteCustomRewardContext(context.Background(), broadcasterID, rewardID)
+}
+
+// DeleteCustomRewardContext is like [Session.DeleteCustomReward] but uses ctx for the API
+// requests.
+func (s *Session) DeleteCustomRewardContext(ctx context.Context, broadcasterID, rewardID string) error {
+	if err := s.requireScope("channel:manage:redemptions"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"id":             {rewardID},
+	}
+	err := s.requestHelper(ctx, http.MethodDelete, "/channel_points/custom_rewards", queryParams, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete custom reward: %w", err)
+	}
+	return nil
+}
+
+// sendCustomReward sends params as the body of a create or update request and returns the reward
+// from the response.
+func (s *Session) sendCustomReward(ctx context.Context, method string, queryParams map[string][]string, params CustomRewardParams) (*CustomReward, error) {
+	body := &bytes.Buffer{}
+	if err := json.NewEncoder(body).Encode(params); err != nil {
+		return nil, fmt.Errorf("encode custom reward: %v", err)
+	}
+
+	var rewardData rawCustomRewardData
+	err := s.requestHelper(ctx, method, "/channel_points/custom_rewards", queryParams, body, &rewardData)
+	if err != nil {
+		return nil, err
+	}
+	if len(rewardData.Data) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+	return rewardData.Data[0], nil
+}
+
+// RedemptionStatus is the status of a channel point reward redemption.
+type RedemptionStatus string
+
+const (
+	// RedemptionStatusUnfulfilled is the status of a redemption, which was not handled yet.
+	RedemptionStatusUnfulfilled RedemptionStatus = "UNFULFILLED"
+	// RedemptionStatusFulfilled is the status of a redemption, which was handled.
+	RedemptionStatusFulfilled RedemptionStatus = "FULFILLED"
+	// RedemptionStatusCanceled is the status of a redemption, which was canceled. The viewer got
+	// their channel points refunded.
+	RedemptionStatusCanceled RedemptionStatus = "CANCELED"
+)
+
+type rawRedemptionData struct {
+	// The list of redemptions.
+	Data       []*Redemption `json:"data"`
+	Pagination pagination    `json:"pagination"`
+}
+
+// Redemption is a viewer redeeming a custom channel point reward.
+type Redemption struct {
+	// An ID that identifies this redemption.
+	ID string `json:"id"`
+	// The ID that uniquely identifies the broadcaster.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+	// The ID that uniquely identifies the user that redeemed the reward.
+	UserID string `json:"user_id"`
+	// The user’s login name.
+	UserLogin string `json:"user_login"`
+	// The user’s display name.
+	UserName string `json:"user_name"`
+
+	// The text the user entered at the prompt when they redeemed the reward; otherwise, an empty
+	// string if user input was not required.
+	UserInput string `json:"user_input"`
+	// The state of the redemption.
+	Status RedemptionStatus `json:"status"`
+	// The date and time of when the reward was redeemed, in RFC3339 format.
+	RedeemedAt time.Time `json:"redeemed_at"`
+	// The reward that the user redeemed.
+	Reward RedemptionReward `json:"reward"`
+}
+
+// RedemptionReward is the short form of the reward of a [Redemption].
+type RedemptionReward struct {
+	// The ID that uniquely identifies the reward.
+	ID string `json:"id"`
+	// The reward’s title.
+	Title string `json:"title"`
+	// The prompt displayed to the viewer if user input is required.
+	Prompt string `json:"prompt"`
+	// The reward’s cost, in Channel Points.
+	Cost int `json:"cost"`
+}
+
+// GetRedemptions gets all redemptions of the given custom reward with the given status, the oldest
+// first. The current session has to have the "channel:read:redemptions" or
+// "channel:manage:redemptions" permission and has to be the broadcaster. Only redemptions of
+// rewards created by the same client ID can be read.
+func (s *Session) GetRedemptions(broadcasterID, rewardID string, status RedemptionStatus) ([]*Redemption, error) {
+	return s.GetRedemptionsContext(context.Background(), broadcasterID, rewardID, status)
+}
+
+// GetRedemptionsContext is like [Session.GetRedemptions] but uses ctx for the API requests.
+func (s *Session) GetRedemptionsContext(ctx context.Context, broadcasterID, rewardID string, status RedemptionStatus) (redemptions []*Redemption, err error) {
+	if err := s.requireScope("channel:read:redemptions", "channel:manage:redemptions"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"reward_id":      {rewardID},
+		"status":         {string(status)},
+		"first":          {"50"},
+	}
+
+	for {
+		var redemptionData rawRedemptionData
+		err = s.requestHelper(ctx, http.MethodGet, "/channel_points/custom_rewards/redemptions", queryParams, nil, &redemptionData)
+		if err != nil {
+			return nil, fmt.Errorf("get redemptions: %w", err)
+		}
+		redemptions = append(redemptions, redemptionData.Data...)
+		if redemptionData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{redemptionData.Pagination.Cursor}
+	}
+	return redemptions, nil
+}
+
+// minPollInterval is the minimum interval of polling functions like [Session.PollRedemptions].
+const minPollInterval = time.Second
+
+// PollRedemptions polls the unfulfilled redemptions of the given custom reward every interval and
+// calls callback for every redemption, which was not seen before. This is a simple alternative to
+// EventSub, which needs no public webhook or WebSocket connection. See [Session.GetRedemptions]
+// for the required permissions.
+//
+// The first poll happens immediately and also reports the redemptions, which were already
+// unfulfilled before. Errors while polling are logged and the next poll is tried as usual.
+// PollRedemptions returns a function to stop polling.
+//
+// Intervals shorter than 1 second are raised to 1 second.
+func (s *Session) PollRedemptions(broadcasterID, rewardID string, interval time.Duration, callback func(*Redemption)) (stop func()) {
+	interval = max(interval, minPollInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		seen := make(map[string]bool)
+		for {
+			redemptions, err := s.GetRedemptionsContext(ctx, broadcasterID, rewardID, RedemptionStatusUnfulfilled)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Failed to poll redemptions of reward %s: %v", rewardID, err)
+			} else if err == nil {
+				// forget redemptions, which are no longer unfulfilled
+				current := make(map[string]bool, len(redemptions))
+				for _, r := range redemptions {
+					current[r.ID] = true
+					if !seen[r.ID] {
+						callback(r)
+					}
+				}
+				seen = current
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// UpdateRedemptionStatus marks the given redemption as fulfilled or, if fulfilled is false, as
+// canceled and returns the updated redemption. Canceling a redemption refunds the channel points
+// of the viewer. The current session has to have the "channel:manage:redemptions" permission and
+// has to be the broadcaster. Only redemptions of rewards created by the same client ID can be
+// updated.
+func (s *Session) UpdateRedemptionStatus(broadcasterID, rewardID, redemptionID string, fulfilled bool) (*Redemption, error) {
+	return s.UpdateRedemptionStatusContext(context.Background(), broadcasterID, rewardID, redemptionID, fulfilled)
+}
+
+// UpdateRedemptionStatusContext is like [Session.UpdateRedemptionStatus] but uses ctx for the API
+// requests.
+func (s *Session) UpdateRedemptionStatusContext(ctx context.Context, broadcasterID, rewardID, redemptionID string, fulfilled bool) (*Redemption, error) {
+	if err := s.requireScope("channel:manage:redemptions"); err != nil {
+		return nil, err
+	}
+
+	status := RedemptionStatusCanceled
+	if fulfilled {
+		status = RedemptionStatusFulfilled
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"reward_id":      {rewardID},
+		"id":             {redemptionID},
+	}
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		Status RedemptionStatus `json:"status"`
+	}{status})
+	if err != nil {
+		return nil, fmt.Errorf("encode redemption status: %v", err)
+	}
+
+	var redemptionData rawRedemptionData
+	err = s.requestHelper(ctx, http.MethodPatch, "/channel_points/custom_rewards/redemptions", queryParams, body, &redemptionData)
+	if err != nil {
+		return nil, fmt.Errorf("update redemption status: %w", err)
+	}
+	if len(redemptionData.Data) == 0 {
+		return nil, fmt.Errorf("update redemption status: empty response")
+	}
+	return redemptionData.Data[0], nil
+}
@@ -0,0 +1,294 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type rawEmoteData struct {
+	// The list of emotes.
+	Data []*Emote `json:"data"`
+	// A templated URL. Used to set Emote.Template.
+	Template string `json:"template"`
+}
+
+// Emote is a Twitch emote that users can use in chat.
+type Emote struct {
+	// An ID that identifies this emote.
+	ID string `json:"id"`
+	// The name of the emote. This is the name that viewers type in the chat window to get the emote
+	// to appear.
+	Name string `json:"name"`
+	// The image URLs for the emote. These image URLs always provide a static, light image.
+	Images EmoteImages `json:"images"`
+
+	// Only set for channel emotes. The subscriber tier at which the emote is unlocked, e.g. "1000"
+	// for tier 1. Empty for other emote types.
+	Tier string `json:"tier"`
+	// Only set for channel emotes. The type of emote. Possible values are:
+	//	"bitstier"     // A custom Bits tier emote.
+	//	"follower"     // A custom follower emote.
+	//	"subscriptions" // A custom subscriber emote.
+	EmoteType string `json:"emote_type"`
+	// Only set for channel emotes. An ID that identifies the emote set that the emote belongs to.
+	EmoteSetID string `json:"emote_set_id"`
+
+	// The formats that the emote is available in. Possible values are "animated" and "static".
+	Format []string `json:"format"`
+	// The sizes that the emote is available in. Possible values are "1.0", "2.0" and "3.0".
+	Scale []string `json:"scale"`
+	// The background themes that the emote is available in. Possible values are "dark" and "light".
+	ThemeMode []string `json:"theme_mode"`
+
+	// The templated URL of the emote, with the placeholders "{{id}}", "{{format}}", "{{scale}}" and
+	// "{{theme_mode}}". Use [Emote.URL] to fill them in.
+	Template string `json:"-"`
+}
+
+// EmoteImages are the URLs of the static, light images of an [Emote] in all three sizes.
+type EmoteImages struct {
+	// A URL to the small version (28px x 28px) of the emote.
+	URL1x string `json:"url_1x"`
+	// A URL to the medium version (56px x 56px) of the emote.
+	URL2x string `json:"url_2x"`
+	// A URL to the large version (112px x 112px) of the emote.
+	URL4x string `json:"url_4x"`
+}
+
+// URL returns the URL of the emote image in the given format, theme mode and scale. See the
+// Format, ThemeMode and Scale fields for the values the emote is available in.
+func (e *Emote) URL(format, themeMode, scale string) string {
+	return strings.NewReplacer(
+		"{{id}}", e.ID,
+		"{{format}}", format,
+		"{{theme_mode}}", themeMode,
+		"{{scale}}", scale,
+	).Replace(e.Template)
+}
+
+// GetGlobalEmotes gets all global emotes. Global emotes are Twitch-created emotes that users can
+// use in any Twitch chat.
+func (s *Session) GetGlobalEmotes() ([]*Emote, error) {
+	return s.GetGlobalEmotesContext(context.Background())
+}
+
+// GetGlobalEmotesContext is like [Session.GetGlobalEmotes] but uses ctx for the API requests.
+func (s *Session) GetGlobalEmotesContext(ctx context.Context) ([]*Emote, error) {
+	emotes, err := s.getEmotes(ctx, "/chat/emotes/global", nil)
+	if err != nil {
+		return nil, fmt.Errorf("get global emotes: %w", err)
+	}
+	return emotes, nil
+}
+
+// GetChannelEmotes gets the custom emotes of the channel of the given broadcaster. These are the
+// subscriber, Bits tier and follower emotes of the channel.
+func (s *Session) GetChannelEmotes(broadcasterID string) ([]*Emote, error) {
+	return s.GetChannelEmotesContext(context.Background(), broadcasterID)
+}
+
+// GetChannelEmotesContext is like [Session.GetChannelEmotes] but uses ctx for the API requests.
+func (s *Session) GetChannelEmotesContext(ctx context.Context, broadcasterID string) ([]*Emote, error) {
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+	emotes, err := s.getEmotes(ctx, "/chat/emotes", queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("get channel emotes: %w", err)
+	}
+	return emotes, nil
+}
+
+// getEmotes requests the emotes from endpoint and sets their template.
+func (s *Session) getEmotes(ctx context.Context, endpoint string, queryParams map[string][]string) ([]*Emote, error) {
+	var emoteData rawEmoteData
+	err := s.requestHelper(ctx, http.MethodGet, endpoint, queryParams, nil, &emoteData)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range emoteData.Data {
+		e.Template = emoteData.Template
+	}
+	return emoteData.Data, nil
+}
+
+type rawBadgeData struct {
+	// The list of badge sets.
+	Data []*BadgeSet `json:"data"`
+}
+
+// BadgeSet is a chat badge with all its versions, e.g. the "subscriber" badge with a version for
+// every subscription tier and duration.
+type BadgeSet struct {
+	// An ID that identifies this set of chat badges, e.g. "bits" or "subscriber".
+	SetID string `json:"set_id"`
+	// The list of chat badges in this set.
+	Versions []BadgeVersion `json:"versions"`
+}
+
+// BadgeVersion is a single version of a chat badge in a [BadgeSet].
+type BadgeVersion struct {
+	// An ID that identifies this version of the badge. The ID can be any value. For example, for
+	// Bits, the ID is the Bits tier level, but for World of Warcraft, it could be "Alliance" or
+	// "Horde".
+	ID string `json:"id"`
+	// A URL to the small version (18px x 18px) of the badge.
+	ImageURL1x string `json:"image_url_1x"`
+	// A URL to the medium version (36px x 36px) of the badge.
+	ImageURL2x string `json:"image_url_2x"`
+	// A URL to the large version (72px x 72px) of the badge.
+	ImageURL4x string `json:"image_url_4x"`
+	// The title of the badge.
+	Title string `json:"title"`
+	// The description of the badge.
+	Description string `json:"description"`
+	// The action to take when clicking on the badge. Empty if no action is specified.
+	ClickAction string `json:"click_action"`
+	// The URL to navigate to when clicking on the badge. Empty if no URL is specified.
+	ClickURL string `json:"click_url"`
+}
+
+// Version returns the version of the badge set with the given ID, or nil if there is none. Use it
+// with the badges of [IRCMessageTags], which are in the form "<set-id>/<version-id>".
+func (b *BadgeSet) Version(id string) *BadgeVersion {
+	for i := range b.Versions {
+		if b.Versions[i].ID == id {
+			return &b.Versions[i]
+		}
+	}
+	return nil
+}
+
+// GetGlobalChatBadges gets all of Twitch’s global chat badges.
+func (s *Session) GetGlobalChatBadges() ([]*BadgeSet, error) {
+	return s.GetGlobalChatBadgesContext(context.Background())
+}
+
+// GetGlobalChatBadgesContext is like [Session.GetGlobalChatBadges] but uses ctx for the API
+// requests.
+func (s *Session) GetGlobalChatBadgesContext(ctx context.Context) ([]*BadgeSet, error) {
+	var badgeData rawBadgeData
+	err := s.requestHelper(ctx, http.MethodGet, "/chat/badges/global", nil, nil, &badgeData)
+	if err != nil {
+		return nil, fmt.Errorf("get global chat badges: %w", err)
+	}
+	return badgeData.Data, nil
+}
+
+// GetChannelChatBadges gets the custom chat badges of the channel of the given broadcaster. These
+// are the subscriber and Bits badges of the channel.
+func (s *Session) GetChannelChatBadges(broadcasterID string) ([]*BadgeSet, error) {
+	return s.GetChannelChatBadgesContext(context.Background(), broadcasterID)
+}
+
+// GetChannelChatBadgesContext is like [Session.GetChannelChatBadges] but uses ctx for the API
+// requests.
+func (s *Session) GetChannelChatBadgesContext(ctx context.Context, broadcasterID string) ([]*BadgeSet, error) {
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+
+	var badgeData rawBadgeData
+	err := s.requestHelper(ctx, http.MethodGet, "/chat/badges", queryParams, nil, &badgeData)
+	if err != nil {
+		return nil, fmt.Errorf("get channel chat badges: %w", err)
+	}
+	return badgeData.Data, nil
+}
+
+type rawChatterData struct {
+	// The list of users that are connected to the chat room.
+	Data       []userReference `json:"data"`
+	Pagination pagination      `json:"pagination"`
+}
+
+// GetChatters gets all the users that are connected to the chat of the given broadcaster. The
+// current session has to have the "moderator:read:chatters" permission and has to be the
+// broadcaster or one of their moderators. If broadcasterID is empty, the chatters of the current
+// user are returned.
+//
+// Twitch updates the list of chatters only every few minutes. The returned users only have their
+// ID, Login and DisplayName set.
+func (s *Session) GetChatters(broadcasterID string) ([]*User, error) {
+	return s.GetChattersContext(context.Background(), broadcasterID)
+}
+
+// GetChattersContext is like [Session.GetChatters] but uses ctx for the API requests.
+func (s *Session) GetChattersContext(ctx context.Context, broadcasterID string) (chatters []*User, err error) {
+	if err := s.requireScope("moderator:read:chatters"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if broadcasterID == "" {
+		broadcasterID = user.ID
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+		"first":          {"1000"},
+	}
+
+	for {
+		var chatterData rawChatterData
+		err = s.requestHelper(ctx, http.MethodGet, "/chat/chatters", queryParams, nil, &chatterData)
+		if err != nil {
+			return nil, fmt.Errorf("get chatters: %w", err)
+		}
+		for _, u := range chatterData.Data {
+			chatters = append(chatters, u.user())
+		}
+		if chatterData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{chatterData.Pagination.Cursor}
+	}
+	return chatters, nil
+}
+
+// SendAnnouncement sends an announcement to the broadcaster’s chat room. msg may contain a maximum
+// of 500 characters. color is the color used to highlight the announcement, one of "blue",
+// "green", "orange" or "purple". If color is empty, the channel’s accent color is used. The
+// current session has to have the "moderator:manage:announcements" permission and has to be the
+// broadcaster or one of their moderators.
+func (s *Session) SendAnnouncement(broadcasterID, msg, color string) error {
+	return s.SendAnnouncementContext(context.Background(), broadcasterID, msg, color)
+}
+
+// SendAnnouncementContext is like [Session.SendAnnouncement] but uses ctx for the API requests.
+func (s *Session) SendAnnouncementContext(ctx context.Context, broadcasterID, msg, color string) error {
+	if err := s.requireScope("moderator:manage:announcements"); err != nil {
+		return err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+	}
+	body := &bytes.Buffer{}
+	err = json.NewEncoder(body).Encode(struct {
+		Message string `json:"message"`
+		Color   string `json:"color,omitempty"`
+	}{msg, color})
+	if err != nil {
+		return fmt.Errorf("encode announcement: %v", err)
+	}
+
+	err = s.requestHelper(ctx, http.MethodPost, "/chat/announcements", queryParams, body, nil)
+	if err != nil {
+		return fmt.Errorf("send announcement: %w", err)
+	}
+	return nil
+}
@@ -2,13 +2,21 @@ package twitchgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"net/http"
 	"net/url"
 	"time"
 )
 
+// ErrSubscriptionExists is returned by [Session.Subscribe], when a subscription with the same
+// type, condition and transport already exists.
+var ErrSubscriptionExists = errors.New("subscription already exists")
+
 // Subscription represents a single subscription to an event.
 type Subscription struct {
 	// ID is the unique identifier for this subscription.
@@ -57,7 +65,7 @@ type SubscriptionTransport struct {
 	WebhookSecret string `json:"secret,omitempty"`
 
 	// WebSocketSessionID is the ID the welcome message returns, when connecting to the twitch
-	// websocket. More information needed.
+	// websocket. See [Session.ConnectEventSub].
 	//
 	// Only when Method == "websocket"
 	WebSocketSessionID string `json:"session_id,omitempty"`
@@ -80,10 +88,42 @@ const (
 	// category, title, content classification labels, or broadcast language
 	// for their channel.
 	EventChannelUpdate SubscriptionType = "channel.update"
-	// EventChannelFollow sends a notification when the specified broadcaster
-	//starts a stream.
+	// EventChannelFollow sends a notification when a specified channel
+	// receives a follow.
+	//
+	// Requires the conditions "broadcaster_user_id" and "moderator_user_id".
+	EventChannelFollow SubscriptionType = "channel.follow"
+	// EventChannelSubscribe sends a notification when a specified channel
+	// receives a subscriber. This does not include resubscribes.
+	EventChannelSubscribe SubscriptionType = "channel.subscribe"
+	// EventChannelSubscriptionGift sends a notification when a viewer gives a
+	// gift subscription to one or more users in the specified channel.
+	EventChannelSubscriptionGift SubscriptionType = "channel.subscription.gift"
+	// EventChannelCheer sends a notification when a user cheers on the
+	// specified channel.
+	EventChannelCheer SubscriptionType = "channel.cheer"
+	// EventChannelRaid sends a notification when a broadcaster raids another
+	// broadcaster’s channel.
+	//
+	// Requires either the condition "from_broadcaster_user_id" or
+	// "to_broadcaster_user_id".
+	EventChannelRaid SubscriptionType = "channel.raid"
+	// EventChannelPointsCustomRewardRedemptionAdd sends a notification when a
+	// viewer has redeemed a custom channel points reward on the specified
+	// channel.
+	EventChannelPointsCustomRewardRedemptionAdd SubscriptionType = "channel.channel_points_custom_reward_redemption.add"
+	// EventChannelBan sends a notification when a viewer is timed out or
+	// banned from the specified channel.
+	EventChannelBan SubscriptionType = "channel.ban"
+	// EventChannelModerate sends a notification when a moderator performs a
+	// moderation action in a channel.
+	//
+	// Requires the conditions "broadcaster_user_id" and "moderator_user_id".
+	EventChannelModerate SubscriptionType = "channel.moderate"
+	// EventStreamOnline sends a notification when the specified broadcaster
+	// starts a stream.
 	EventStreamOnline SubscriptionType = "stream.online"
-	// EventChannelFollow sends a notification when the specified broadcaster
+	// EventStreamOffline sends a notification when the specified broadcaster
 	// stops a stream.
 	EventStreamOffline SubscriptionType = "stream.offline"
 )
@@ -92,6 +132,22 @@ func (st SubscriptionType) GetVersion() string {
 	switch st {
 	case EventChannelUpdate:
 		return "2"
+	case EventChannelFollow:
+		return "2"
+	case EventChannelSubscribe:
+		return "1"
+	case EventChannelSubscriptionGift:
+		return "1"
+	case EventChannelCheer:
+		return "1"
+	case EventChannelRaid:
+		return "1"
+	case EventChannelPointsCustomRewardRedemptionAdd:
+		return "1"
+	case EventChannelBan:
+		return "1"
+	case EventChannelModerate:
+		return "2"
 	case EventStreamOnline:
 		return "1"
 	case EventStreamOffline:
@@ -168,23 +224,70 @@ const (
 	SubscriptionStatusWebSocketNetworkError SubscriptionStatus = "websocket_network_error"
 )
 
+type rawSubscriptionData struct {
+	// The list of subscriptions.
+	Data []*Subscription `json:"data"`
+	// The total number of subscriptions that you’ve created.
+	Total int `json:"total"`
+	// The sum of all of your subscription costs.
+	TotalCost int `json:"total_cost"`
+	// The maximum total cost that you’re allowed to incur for all subscriptions you create.
+	MaxTotalCost int        `json:"max_total_cost"`
+	Pagination   pagination `json:"pagination"`
+}
+
 // GetSubscriptions returns all subscriptions for the authenticated user.
 //
 // If onlyEnabled is set to true, only enabled subscriptions are returned.
 func (s *Session) GetSubscriptions(onlyEnabled bool) (subscriptions []*Subscription, err error) {
-	subscriptionsResult := struct {
-		Data       []*Subscription `json:"data"`
-		Pagination pagination      `json:"pagination"`
-	}{}
+	return s.GetSubscriptionsContext(context.Background(), onlyEnabled)
+}
 
+// GetSubscriptionsContext is like [Session.GetSubscriptions] but uses ctx for the API requests.
+func (s *Session) GetSubscriptionsContext(ctx context.Context, onlyEnabled bool) (subscriptions []*Subscription, err error) {
 	queryParams := make(url.Values)
 	if onlyEnabled {
-		queryParams.Set("status", "enabled")
+		queryParams.Set("status", string(SubscriptionStatusEnabled))
 	}
+	return s.getSubscriptions(ctx, queryParams)
+}
+
+// GetSubscriptionsByStatus returns all subscriptions for the authenticated user with the given
+// status, e.g. all subscriptions which failed to verify their webhook callback.
+func (s *Session) GetSubscriptionsByStatus(status SubscriptionStatus) ([]*Subscription, error) {
+	return s.GetSubscriptionsByStatusContext(context.Background(), status)
+}
+
+// GetSubscriptionsByStatusContext is like [Session.GetSubscriptionsByStatus] but uses ctx for the
+// API requests.
+func (s *Session) GetSubscriptionsByStatusContext(ctx context.Context, status SubscriptionStatus) ([]*Subscription, error) {
+	queryParams := make(url.Values)
+	queryParams.Set("status", string(status))
+	return s.getSubscriptions(ctx, queryParams)
+}
+
+// GetSubscriptionsByType returns all subscriptions for the authenticated user to the given event
+// type.
+func (s *Session) GetSubscriptionsByType(event SubscriptionType) ([]*Subscription, error) {
+	return s.GetSubscriptionsByTypeContext(context.Background(), event)
+}
+
+// GetSubscriptionsByTypeContext is like [Session.GetSubscriptionsByType] but uses ctx for the API
+// requests.
+func (s *Session) GetSubscriptionsByTypeContext(ctx context.Context, event SubscriptionType) ([]*Subscription, error) {
+	queryParams := make(url.Values)
+	queryParams.Set("type", string(event))
+	return s.getSubscriptions(ctx, queryParams)
+}
+
+// getSubscriptions pages through all subscriptions matching the filter in queryParams. Twitch
+// allows only one filter per request.
+func (s *Session) getSubscriptions(ctx context.Context, queryParams url.Values) (subscriptions []*Subscription, err error) {
 	for {
-		err = s.requestHelper("GET", "/eventsub/subscriptions", queryParams, nil, &subscriptionsResult)
+		var subscriptionsResult rawSubscriptionData
+		err = s.requestHelper(ctx, "GET", "/eventsub/subscriptions", queryParams, nil, &subscriptionsResult)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("get subscriptions: %w", err)
 		}
 		subscriptions = append(subscriptions, subscriptionsResult.Data...)
 		if subscriptionsResult.Pagination.Cursor == "" {
@@ -195,34 +298,190 @@ func (s *Session) GetSubscriptions(onlyEnabled bool) (subscriptions []*Subscript
 	return subscriptions, nil
 }
 
+// GetSubscriptionCost returns how much of the EventSub quota is used. total is the number of
+// subscriptions created, totalCost is the sum of all of their costs and maxTotalCost is the maximum
+// total cost allowed. Check these before creating new subscriptions to avoid hitting the limit.
+func (s *Session) GetSubscriptionCost() (total, totalCost, maxTotalCost int, err error) {
+	return s.GetSubscriptionCostContext(context.Background())
+}
+
+// GetSubscriptionCostContext is like [Session.GetSubscriptionCost] but uses ctx for the API
+// requests.
+func (s *Session) GetSubscriptionCostContext(ctx context.Context) (total, totalCost, maxTotalCost int, err error) {
+	var subscriptionsResult rawSubscriptionData
+	err = s.requestHelper(ctx, "GET", "/eventsub/subscriptions", nil, nil, &subscriptionsResult)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("get subscription cost: %w", err)
+	}
+	return subscriptionsResult.Total, subscriptionsResult.TotalCost, subscriptionsResult.MaxTotalCost, nil
+}
+
 // DeleteSubscription deletes the subscription with the specified ID.
 func (s *Session) DeleteSubscription(id string) (err error) {
+	return s.DeleteSubscriptionContext(context.Background(), id)
+}
+
+// DeleteSubscriptionContext is like [Session.DeleteSubscription] but uses ctx for the API requests.
+func (s *Session) DeleteSubscriptionContext(ctx context.Context, id string) (err error) {
 	queryParams := make(url.Values)
 	queryParams.Set("id", id)
-	return s.requestHelper("DELETE", "/eventsub/subscriptions", queryParams, nil, nil)
+	return s.requestHelper(ctx, "DELETE", "/eventsub/subscriptions", queryParams, nil, nil)
 }
 
-// SubscribeToEvent is a helper function to subscribe to the specified event.
-func (s *Session) SubscribeToEvent(broadcasterID, callbackURL string, event SubscriptionType) (err error) {
+// DeleteAllSubscriptions deletes all subscriptions of the authenticated user and returns the
+// number of deleted subscriptions. This is useful when the callback URL changes. If an error
+// occurs, the subscriptions deleted before are still counted.
+func (s *Session) DeleteAllSubscriptions() (deleted int, err error) {
+	return s.DeleteAllSubscriptionsContext(context.Background())
+}
+
+// DeleteAllSubscriptionsContext is like [Session.DeleteAllSubscriptions] but uses ctx for the API
+// requests.
+func (s *Session) DeleteAllSubscriptionsContext(ctx context.Context) (deleted int, err error) {
+	subscriptions, err := s.GetSubscriptionsContext(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	return s.deleteSubscriptions(ctx, subscriptions)
+}
+
+// DeleteSubscriptionsByStatus deletes all subscriptions of the authenticated user with the given
+// status and returns the number of deleted subscriptions. Use it to purge failed or revoked
+// subscriptions.
+func (s *Session) DeleteSubscriptionsByStatus(status SubscriptionStatus) (deleted int, err error) {
+	return s.DeleteSubscriptionsByStatusContext(context.Background(), status)
+}
+
+// DeleteSubscriptionsByStatusContext is like [Session.DeleteSubscriptionsByStatus] but uses ctx
+// for the API requests.
+func (s *Session) DeleteSubscriptionsByStatusContext(ctx context.Context, status SubscriptionStatus) (deleted int, err error) {
+	subscriptions, err := s.GetSubscriptionsByStatusContext(ctx, status)
+	if err != nil {
+		return 0, err
+	}
+	return s.deleteSubscriptions(ctx, subscriptions)
+}
+
+// deleteSubscriptions deletes all the given subscriptions one by one.
+func (s *Session) deleteSubscriptions(ctx context.Context, subscriptions []*Subscription) (deleted int, err error) {
+	for _, sub := range subscriptions {
+		if err = s.DeleteSubscriptionContext(ctx, sub.ID); err != nil {
+			return deleted, fmt.Errorf("delete subscription %s: %w", sub.ID, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// Subscribe creates a subscription to the specified event and returns it, including its ID.
+//
+// The required keys of condition depend on the event type, see the condition builders like
+// [ConditionChannelFollow]. If the transport uses the webhook method and has no secret set, the
+// secret set by [Session.SetWebhookSecret] is used.
+//
+// Returns [ErrSubscriptionExists] if the same subscription already exists. See
+// [Session.SubscribeIfNotExists] to get the existing subscription instead.
+func (s *Session) Subscribe(event SubscriptionType, condition map[string]string, transport SubscriptionTransport) (*Subscription, error) {
+	return s.SubscribeContext(context.Background(), event, condition, transport)
+}
+
+// SubscribeContext is like [Session.Subscribe] but uses ctx for the API requests.
+func (s *Session) SubscribeContext(ctx context.Context, event SubscriptionType, condition map[string]string, transport SubscriptionTransport) (*Subscription, error) {
+	if transport.Method == SubscriptionTransportMethodWebhook && transport.WebhookSecret == "" {
+		transport.WebhookSecret = s.webhookSecret
+	}
+
 	subData := &Subscription{
-		Type:    event,
-		Version: event.GetVersion(),
-		Condition: map[string]string{
-			"broadcaster_user_id": broadcasterID,
-		},
-		Transport: SubscriptionTransport{
-			Method:             SubscriptionTransportMethodWebhook,
-			WebhookCallbackURI: callbackURL,
-			WebhookSecret:      s.webhookSecret,
-		},
+		Type:      event,
+		Version:   event.GetVersion(),
+		Condition: condition,
+		Transport: transport,
 	}
 	body := &bytes.Buffer{}
-	err = json.NewEncoder(body).Encode(subData)
+	err := json.NewEncoder(body).Encode(subData)
 	if err != nil {
-		return fmt.Errorf("encode subscription data: %v", err)
+		return nil, fmt.Errorf("encode subscription data: %v", err)
 	}
 
-	return s.requestHelper("POST", "/eventsub/subscriptions", nil, body, nil)
+	subscriptionResult := struct {
+		Data []*Subscription `json:"data"`
+	}{}
+	err = s.requestHelper(ctx, "POST", "/eventsub/subscriptions", nil, body, &subscriptionResult)
+	if statusCode(err) == http.StatusConflict {
+		return nil, fmt.Errorf("subscribe to %s: %w", event, ErrSubscriptionExists)
+	} else if err != nil {
+		return nil, err
+	}
+	if len(subscriptionResult.Data) == 0 {
+		return nil, fmt.Errorf("subscribe to %s: empty response", event)
+	}
+	return subscriptionResult.Data[0], nil
+}
+
+// SubscribeIfNotExists is like [Session.Subscribe], but returns the existing subscription instead
+// of [ErrSubscriptionExists], if the same subscription already exists. This makes it safe to
+// subscribe on every start of the bot.
+func (s *Session) SubscribeIfNotExists(event SubscriptionType, condition map[string]string, transport SubscriptionTransport) (*Subscription, error) {
+	return s.SubscribeIfNotExistsContext(context.Background(), event, condition, transport)
+}
+
+// SubscribeIfNotExistsContext is like [Session.SubscribeIfNotExists] but uses ctx for the API
+// requests.
+func (s *Session) SubscribeIfNotExistsContext(ctx context.Context, event SubscriptionType, condition map[string]string, transport SubscriptionTransport) (*Subscription, error) {
+	sub, err := s.SubscribeContext(ctx, event, condition, transport)
+	if !errors.Is(err, ErrSubscriptionExists) {
+		return sub, err
+	}
+
+	subscriptions, err := s.GetSubscriptionsByTypeContext(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subscriptions {
+		if sub.Version == event.GetVersion() &&
+			maps.Equal(sub.Condition, condition) &&
+			sub.Transport.Method == transport.Method &&
+			sub.Transport.WebhookCallbackURI == transport.WebhookCallbackURI &&
+			sub.Transport.WebSocketSessionID == transport.WebSocketSessionID {
+			return sub, nil
+		}
+	}
+	return nil, fmt.Errorf("subscribe to %s: %w, but it was not found", event, ErrSubscriptionExists)
+}
+
+// SubscribeToEvent is a helper function to subscribe to the specified event.
+//
+// If callbackURL is empty, the event is subscribed using the WebSocket transport. This requires a
+// connection established by [Session.ConnectEventSub], otherwise [ErrEventSubNotConnected] is
+// returned.
+func (s *Session) SubscribeToEvent(broadcasterID, callbackURL string, event SubscriptionType) (err error) {
+	return s.SubscribeToEventContext(context.Background(), broadcasterID, callbackURL, event)
+}
+
+// SubscribeToEventContext is like [Session.SubscribeToEvent] but uses ctx for the API requests.
+func (s *Session) SubscribeToEventContext(ctx context.Context, broadcasterID, callbackURL string, event SubscriptionType) (err error) {
+	transport := SubscriptionTransport{
+		Method:             SubscriptionTransportMethodWebhook,
+		WebhookCallbackURI: callbackURL,
+	}
+	if callbackURL == "" {
+		s.eventSubMu.Lock()
+		sessionID := s.eventSubSessionID
+		s.eventSubMu.Unlock()
+		if sessionID == "" {
+			return ErrEventSubNotConnected
+		}
+		transport = SubscriptionTransport{
+			Method:             SubscriptionTransportMethodWebSocket,
+			WebSocketSessionID: sessionID,
+		}
+	}
+
+	condition := map[string]string{
+		"broadcaster_user_id": broadcasterID,
+	}
+	_, err = s.SubscribeContext(ctx, event, condition, transport)
+	return err
 }
 
 // SubscribeChannelUpdate subscribes to the channel update event.
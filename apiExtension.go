@@ -0,0 +1,120 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type rawExtensionTransactionData struct {
+	// The list of transactions.
+	Data       []*ExtensionTransaction `json:"data"`
+	Pagination pagination              `json:"pagination"`
+}
+
+// ExtensionTransaction is a purchase of a Bits product in an extension.
+type ExtensionTransaction struct {
+	// An ID that identifies the transaction.
+	ID string `json:"id"`
+	// The UTC date and time of the transaction.
+	Timestamp time.Time `json:"timestamp"`
+
+	// The ID of the broadcaster that owns the channel where the transaction occurred.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+
+	// The ID of the user that purchased the digital product.
+	UserID string `json:"user_id"`
+	// The user’s login name.
+	UserLogin string `json:"user_login"`
+	// The user’s display name.
+	UserName string `json:"user_name"`
+
+	// The type of transaction. Possible values are:
+	//
+	//  "BITS_IN_EXTENSION"
+	ProductType string `json:"product_type"`
+	// Contains details about the digital product.
+	ProductData ExtensionProduct `json:"product_data"`
+}
+
+// ExtensionProduct is a digital product of an extension, which can be bought with Bits.
+type ExtensionProduct struct {
+	// Set to "twitch.ext." + <the extension's ID>.
+	Domain string `json:"domain"`
+	// An ID that identifies the digital product.
+	SKU string `json:"sku"`
+	// Contains details about the digital product’s cost.
+	Cost ExtensionProductCost `json:"cost"`
+	// A Boolean value that determines whether the product is in development.
+	InDevelopment bool `json:"inDevelopment"`
+	// The name of the digital product.
+	DisplayName string `json:"displayName"`
+	// This field is always empty since you may purchase only unexpired products.
+	Expiration string `json:"expiration"`
+	// A Boolean value that determines whether the data was broadcast to all instances of the
+	// extension.
+	Broadcast bool `json:"broadcast"`
+}
+
+// ExtensionProductCost is the cost of an [ExtensionProduct].
+type ExtensionProductCost struct {
+	// The amount exchanged for the digital product.
+	Amount int `json:"amount"`
+	// The type of currency exchanged. Possible values are:
+	//
+	//  "bits"
+	Type string `json:"type"`
+}
+
+// GetExtensionTransactions gets the Bits transactions of the given extension, the most recent
+// first. If transactionIDs are given, only those transactions are returned. More than 100 IDs are
+// split into multiple requests. The current session has to use the app access token of the
+// extension’s client.
+func (s *Session) GetExtensionTransactions(extensionID string, transactionIDs ...string) ([]*ExtensionTransaction, error) {
+	return s.GetExtensionTransactionsContext(context.Background(), extensionID, transactionIDs...)
+}
+
+// GetExtensionTransactionsContext is like [Session.GetExtensionTransactions] but uses ctx for the
+// API requests.
+func (s *Session) GetExtensionTransactionsContext(ctx context.Context, extensionID string, transactionIDs ...string) (transactions []*ExtensionTransaction, err error) {
+	if len(transactionIDs) > 0 {
+		for _, batch := range chunk(transactionIDs, maxIDsPerRequest) {
+			queryParams := map[string][]string{
+				"extension_id": {extensionID},
+				"id":           batch,
+			}
+
+			var transactionData rawExtensionTransactionData
+			err = s.requestHelper(ctx, http.MethodGet, "/extensions/transactions", queryParams, nil, &transactionData)
+			if err != nil {
+				return nil, fmt.Errorf("get extension transactions: %w", err)
+			}
+			transactions = append(transactions, transactionData.Data...)
+		}
+		return transactions, nil
+	}
+
+	queryParams := map[string][]string{
+		"extension_id": {extensionID},
+		"first":        {"100"},
+	}
+
+	for {
+		var transactionData rawExtensionTransactionData
+		err = s.requestHelper(ctx, http.MethodGet, "/extensions/transactions", queryParams, nil, &transactionData)
+		if err != nil {
+			return nil, fmt.Errorf("get extension transactions: %w", err)
+		}
+		transactions = append(transactions, transactionData.Data...)
+		if transactionData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{transactionData.Pagination.Cursor}
+	}
+	return transactions, nil
+}
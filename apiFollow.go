@@ -0,0 +1,55 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type rawFollowData struct {
+	// The list of follows. Only the fields relevant for [Session.IsFollowing] are decoded.
+	Data []struct {
+		// The UTC timestamp when the user started following the broadcaster.
+		FollowedAt time.Time `json:"followed_at"`
+	} `json:"data"`
+}
+
+// IsFollowing reports whether the given user follows the given broadcaster and since when.
+//
+// The check works with two different permissions:
+//   - "moderator:read:followers": The current session has to be the broadcaster or one of their
+//     moderators. Any user can be checked.
+//   - "user:read:follows": The current session has to be the user. Only the follows of the current
+//     user can be checked.
+//
+// If the current session has both permissions, "moderator:read:followers" is used.
+func (s *Session) IsFollowing(userID, broadcasterID string) (bool, time.Time, error) {
+	return s.IsFollowingContext(context.Background(), userID, broadcasterID)
+}
+
+// IsFollowingContext is like [Session.IsFollowing] but uses ctx for the API requests.
+func (s *Session) IsFollowingContext(ctx context.Context, userID, broadcasterID string) (bool, time.Time, error) {
+	if err := s.requireScope("moderator:read:followers", "user:read:follows"); err != nil {
+		return false, time.Time{}, err
+	}
+
+	queryParams := map[string][]string{
+		"user_id":        {userID},
+		"broadcaster_id": {broadcasterID},
+	}
+	endpoint := "/channels/followed"
+	if s.oauth.HasScope("moderator:read:followers") {
+		endpoint = "/channels/followers"
+	}
+
+	var followData rawFollowData
+	err := s.requestHelper(ctx, http.MethodGet, endpoint, queryParams, nil, &followData)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("get follow: %w", err)
+	}
+	if len(followData.Data) == 0 {
+		return false, time.Time{}, nil
+	}
+	return true, followData.Data[0].FollowedAt, nil
+}
@@ -1,11 +1,23 @@
 package twitchgo
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
-// DeleteNessage tries to delete the given message from the broadcaster's chat. The current session
-// has to have the "moderator:manage:chat_message" permission.
+// DeleteMessage tries to delete the given message from the broadcaster's chat. The current session
+// has to have the "moderator:manage:chat_messages" permission.
 func (s *Session) DeleteMessage(broadcasterID, msgID string) (err error) {
-	user, err := s.GetUser()
+	return s.DeleteMessageContext(context.Background(), broadcasterID, msgID)
+}
+
+// DeleteMessageContext is like [Session.DeleteMessage] but uses ctx for the API requests.
+func (s *Session) DeleteMessageContext(ctx context.Context, broadcasterID, msgID string) (err error) {
+	if err := s.requireScope("moderator:manage:chat_messages"); err != nil {
+		return err
+	}
+
+	user, err := s.GetUserContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -19,5 +31,5 @@ func (s *Session) DeleteMessage(broadcasterID, msgID string) (err error) {
 		"message_id":     {msgID},
 	}
 
-	return s.requestHelper(http.MethodDelete, "/moderation/chat", queryParams, nil, nil)
+	return s.requestHelper(ctx, http.MethodDelete, "/moderation/chat", queryParams, nil, nil)
 }
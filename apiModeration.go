@@ -0,0 +1,440 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrUserIsVIP is returned by [Session.AddModerator] when the user is a VIP. To make them a
+	// moderator, they have to be removed as a VIP first.
+	ErrUserIsVIP = errors.New("user is a VIP")
+
+	// ErrModeratorRateLimited is returned by [Session.AddModerator] and [Session.RemoveModerator]
+	// when the broadcaster exceeded the number of moderators that may be added or removed within a
+	// 10-second window.
+	ErrModeratorRateLimited = errors.New("too many moderator changes within 10 seconds")
+
+	// ErrInvalidTimeout is returned by [Session.BanUser] and [Session.Timeout] when the duration of
+	// a timeout is not between 1 second and 2 weeks.
+	ErrInvalidTimeout = errors.New("timeout must be between 1 second and 2 weeks")
+)
+
+type rawModeratorData struct {
+	// The list of moderators.
+	Data       []userReference `json:"data"`
+	Pagination pagination      `json:"pagination"`
+}
+
+// AddModerator adds the user as a moderator to the broadcaster's chat room. The current session
+// has to have the "channel:manage:moderators" permission.
+//
+// Returns [ErrUserIsVIP] if the user is a VIP and [ErrModeratorRateLimited] if too many moderators
+// were added in a short period of time.
+func (s *Session) AddModerator(broadcasterID, userID string) error {
+	return s.AddModeratorContext(context.Background(), broadcasterID, userID)
+}
+
+// AddModeratorContext is like [Session.AddModerator] but uses ctx for the API requests.
+func (s *Session) AddModeratorContext(ctx context.Context, broadcasterID, userID string) error {
+	if err := s.requireScope("channel:manage:moderators"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"user_id":        {userID},
+	}
+
+	err := s.requestHelper(ctx, http.MethodPost, "/moderation/moderators", queryParams, nil, nil)
+	switch statusCode(err) {
+	case 0:
+		return nil
+	case http.StatusUnprocessableEntity:
+		return ErrUserIsVIP
+	case http.StatusTooManyRequests:
+		return ErrModeratorRateLimited
+	default:
+		return fmt.Errorf("add moderator: %w", err)
+	}
+}
+
+// RemoveModerator removes the user as a moderator from the broadcaster's chat room. The current
+// session has to have the "channel:manage:moderators" permission.
+//
+// Returns [ErrModeratorRateLimited] if too many moderators were removed in a short period of
+// time.
+func (s *Session) RemoveModerator(broadcasterID, userID string) error {
+	return s.RemoveModeratorContext(context.Background(), broadcasterID, userID)
+}
+
+// RemoveModeratorContext is like [Session.RemoveModerator] but uses ctx for the API requests.
+func (s *Session) RemoveModeratorContext(ctx context.Context, broadcasterID, userID string) error {
+	if err := s.requireScope("channel:manage:moderators"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"user_id":        {userID},
+	}
+
+	err := s.requestHelper(ctx, http.MethodDelete, "/moderation/moderators", queryParams, nil, nil)
+	switch statusCode(err) {
+	case 0:
+		return nil
+	case http.StatusTooManyRequests:
+		return ErrModeratorRateLimited
+	default:
+		return fmt.Errorf("remove moderator: %w", err)
+	}
+}
+
+// GetModerators gets all moderators of the broadcaster's chat room. The current session has to
+// have the "moderation:read" or "channel:manage:moderators" permission.
+//
+// The returned users only have their ID, Login and DisplayName set.
+func (s *Session) GetModerators(broadcasterID string) (moderators []*User, err error) {
+	return s.GetModeratorsContext(context.Background(), broadcasterID)
+}
+
+// GetModeratorsContext is like [Session.GetModerators] but uses ctx for the API requests.
+func (s *Session) GetModeratorsContext(ctx context.Context, broadcasterID string) (moderators []*User, err error) {
+	if err := s.requireScope("moderation:read", "channel:manage:moderators"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"first":          {"100"},
+	}
+
+	for {
+		var moderatorData rawModeratorData
+		err = s.requestHelper(ctx, http.MethodGet, "/moderation/moderators", queryParams, nil, &moderatorData)
+		if err != nil {
+			return nil, fmt.Errorf("get moderators: %w", err)
+		}
+		for _, u := range moderatorData.Data {
+			moderators = append(moderators, u.user())
+		}
+		if moderatorData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{moderatorData.Pagination.Cursor}
+	}
+	return moderators, nil
+}
+
+// ManageHeldMessage allows or denies a chat message, which AutoMod held for review. userID is the
+// ID of the moderator reviewing the message. It has to match the user of the current session, if
+// empty the current user is used. The current session has to have the "moderator:manage:automod"
+// permission.
+func (s *Session) ManageHeldMessage(userID, msgID string, allow bool) error {
+	return s.ManageHeldMessageContext(context.Background(), userID, msgID, allow)
+}
+
+// ManageHeldMessageContext is like [Session.ManageHeldMessage] but uses ctx for the API requests.
+func (s *Session) ManageHeldMessageContext(ctx context.Context, userID, msgID string, allow bool) error {
+	if err := s.requireScope("moderator:manage:automod"); err != nil {
+		return err
+	}
+
+	if userID == "" {
+		user, err := s.GetUserContext(ctx)
+		if err != nil {
+			return err
+		}
+		userID = user.ID
+	}
+
+	action := "DENY"
+	if allow {
+		action = "ALLOW"
+	}
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		UserID string `json:"user_id"`
+		MsgID  string `json:"msg_id"`
+		Action string `json:"action"`
+	}{userID, msgID, action})
+	if err != nil {
+		return fmt.Errorf("encode held message action: %v", err)
+	}
+
+	err = s.requestHelper(ctx, http.MethodPost, "/moderation/automod/message", nil, body, nil)
+	if err != nil {
+		return fmt.Errorf("manage held message: %w", err)
+	}
+	return nil
+}
+
+// ShieldModeStatus is the status of the Shield Mode of a broadcaster’s chat room.
+type ShieldModeStatus struct {
+	// A Boolean value that determines whether Shield Mode is active.
+	IsActive bool
+	// The moderator that last activated Shield Mode. Only ID, Login and DisplayName are set. Is nil
+	// if Shield Mode was never activated.
+	Moderator *User
+	// The UTC timestamp of when Shield Mode was last activated. Is the zero time if Shield Mode was
+	// never activated.
+	LastActivatedAt time.Time
+}
+
+type rawShieldModeData struct {
+	Data []struct {
+		IsActive       bool   `json:"is_active"`
+		ModeratorID    string `json:"moderator_id"`
+		ModeratorLogin string `json:"moderator_login"`
+		ModeratorName  string `json:"moderator_name"`
+		// empty, if Shield Mode was never activated
+		LastActivatedAt string `json:"last_activated_at"`
+	} `json:"data"`
+}
+
+// status converts the response into a ShieldModeStatus.
+func (d rawShieldModeData) status() (*ShieldModeStatus, error) {
+	if len(d.Data) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+	raw := d.Data[0]
+	status := &ShieldModeStatus{IsActive: raw.IsActive}
+	if raw.ModeratorID != "" {
+		status.Moderator = userReference{raw.ModeratorID, raw.ModeratorLogin, raw.ModeratorName}.user()
+	}
+	if raw.LastActivatedAt != "" {
+		t, err := time.Parse(time.RFC3339, raw.LastActivatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse last activation: %v", err)
+		}
+		status.LastActivatedAt = t
+	}
+	return status, nil
+}
+
+// GetShieldMode gets the Shield Mode status of the broadcaster’s chat room. The current session
+// has to have the "moderator:read:shield_mode" or "moderator:manage:shield_mode" permission and
+// has to be the broadcaster or one of their moderators.
+func (s *Session) GetShieldMode(broadcasterID string) (*ShieldModeStatus, error) {
+	return s.GetShieldModeContext(context.Background(), broadcasterID)
+}
+
+// GetShieldModeContext is like [Session.GetShieldMode] but uses ctx for the API requests.
+func (s *Session) GetShieldModeContext(ctx context.Context, broadcasterID string) (*ShieldModeStatus, error) {
+	if err := s.requireScope("moderator:read:shield_mode", "moderator:manage:shield_mode"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+	}
+
+	var shieldModeData rawShieldModeData
+	err = s.requestHelper(ctx, http.MethodGet, "/moderation/shield_mode", queryParams, nil, &shieldModeData)
+	if err != nil {
+		return nil, fmt.Errorf("get shield mode: %w", err)
+	}
+	return shieldModeData.status()
+}
+
+// UpdateShieldMode activates or deactivates the Shield Mode of the broadcaster’s chat room and
+// returns the new status. The current session has to have the "moderator:manage:shield_mode"
+// permission and has to be the broadcaster or one of their moderators.
+func (s *Session) UpdateShieldMode(broadcasterID string, active bool) (*ShieldModeStatus, error) {
+	return s.UpdateShieldModeContext(context.Background(), broadcasterID, active)
+}
+
+// UpdateShieldModeContext is like [Session.UpdateShieldMode] but uses ctx for the API requests.
+func (s *Session) UpdateShieldModeContext(ctx context.Context, broadcasterID string, active bool) (*ShieldModeStatus, error) {
+	if err := s.requireScope("moderator:manage:shield_mode"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+	}
+	body := &bytes.Buffer{}
+	err = json.NewEncoder(body).Encode(struct {
+		IsActive bool `json:"is_active"`
+	}{active})
+	if err != nil {
+		return nil, fmt.Errorf("encode shield mode: %v", err)
+	}
+
+	var shieldModeData rawShieldModeData
+	err = s.requestHelper(ctx, http.MethodPut, "/moderation/shield_mode", queryParams, body, &shieldModeData)
+	if err != nil {
+		return nil, fmt.Errorf("update shield mode: %w", err)
+	}
+	return shieldModeData.status()
+}
+
+type rawWarningData struct {
+	// A list that contains the warning that was sent.
+	Data []*Warning `json:"data"`
+}
+
+// Warning is a warning sent to a user in a broadcaster’s chat room.
+type Warning struct {
+	// The ID of the channel in which the warning will take effect.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The ID of the warned user.
+	UserID string `json:"user_id"`
+	// The ID of the user who applied the warning.
+	ModeratorID string `json:"moderator_id"`
+	// The reason provided for warning.
+	Reason string `json:"reason"`
+}
+
+// WarnUser warns the user in the broadcaster’s chat room and returns the warning. The user has to
+// acknowledge the warning before they can chat again. reason is shown to the user and may contain
+// a maximum of 500 characters. The current session has to have the "moderator:manage:warnings"
+// permission and has to be the broadcaster or one of their moderators.
+func (s *Session) WarnUser(broadcasterID, userID, reason string) (*Warning, error) {
+	return s.WarnUserContext(context.Background(), broadcasterID, userID, reason)
+}
+
+// WarnUserContext is like [Session.WarnUser] but uses ctx for the API requests.
+func (s *Session) WarnUserContext(ctx context.Context, broadcasterID, userID, reason string) (*Warning, error) {
+	if err := s.requireScope("moderator:manage:warnings"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+	}
+	type warning struct {
+		UserID string `json:"user_id"`
+		Reason string `json:"reason"`
+	}
+	body := &bytes.Buffer{}
+	err = json.NewEncoder(body).Encode(struct {
+		Data warning `json:"data"`
+	}{warning{userID, reason}})
+	if err != nil {
+		return nil, fmt.Errorf("encode warning: %v", err)
+	}
+
+	var warningData rawWarningData
+	err = s.requestHelper(ctx, http.MethodPost, "/moderation/warnings", queryParams, body, &warningData)
+	if err != nil {
+		return nil, fmt.Errorf("warn user: %w", err)
+	}
+	if len(warningData.Data) == 0 {
+		return nil, fmt.Errorf("warn user: empty response")
+	}
+	return warningData.Data[0], nil
+}
+
+// maxTimeout is the longest timeout Twitch allows.
+const maxTimeout = 14 * 24 * time.Hour
+
+// timeoutSeconds returns the duration of a timeout in whole seconds. It returns
+// [ErrInvalidTimeout], if duration is not between 1 second and 2 weeks. A duration of 0 stands for
+// a permanent ban and is returned as 0.
+func timeoutSeconds(duration time.Duration) (int, error) {
+	if duration == 0 {
+		return 0, nil
+	}
+	if duration < time.Second || duration > maxTimeout {
+		return 0, fmt.Errorf("%w: got %s", ErrInvalidTimeout, duration)
+	}
+	return int(duration.Seconds()), nil
+}
+
+// BanUser bans the user from the broadcaster’s chat room or puts them in a timeout. A duration of
+// 0 bans the user permanently, otherwise the user is put in a timeout for duration, between 1
+// second and 2 weeks, or [ErrInvalidTimeout] is returned. reason is optional and may contain a
+// maximum of 500 characters. The current session has to have the "moderator:manage:banned_users"
+// permission and has to be the broadcaster or one of their moderators.
+func (s *Session) BanUser(broadcasterID, userID string, duration time.Duration, reason string) error {
+	return s.BanUserContext(context.Background(), broadcasterID, userID, duration, reason)
+}
+
+// BanUserContext is like [Session.BanUser] but uses ctx for the API requests.
+func (s *Session) BanUserContext(ctx context.Context, broadcasterID, userID string, duration time.Duration, reason string) error {
+	if err := s.requireScope("moderator:manage:banned_users"); err != nil {
+		return err
+	}
+	seconds, err := timeoutSeconds(duration)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+	}
+	type ban struct {
+		UserID   string `json:"user_id"`
+		Duration int    `json:"duration,omitempty"`
+		Reason   string `json:"reason,omitempty"`
+	}
+	body := &bytes.Buffer{}
+	err = json.NewEncoder(body).Encode(struct {
+		Data ban `json:"data"`
+	}{ban{userID, seconds, reason}})
+	if err != nil {
+		return fmt.Errorf("encode ban: %v", err)
+	}
+
+	err = s.requestHelper(ctx, http.MethodPost, "/moderation/bans", queryParams, body, nil)
+	if err != nil {
+		return fmt.Errorf("ban user: %w", err)
+	}
+	return nil
+}
+
+// UnbanUser removes the ban or timeout of the user in the broadcaster’s chat room. The current
+// session has to have the "moderator:manage:banned_users" permission and has to be the broadcaster
+// or one of their moderators.
+func (s *Session) UnbanUser(broadcasterID, userID string) error {
+	return s.UnbanUserContext(context.Background(), broadcasterID, userID)
+}
+
+// UnbanUserContext is like [Session.UnbanUser] but uses ctx for the API requests.
+func (s *Session) UnbanUserContext(ctx context.Context, broadcasterID, userID string) error {
+	if err := s.requireScope("moderator:manage:banned_users"); err != nil {
+		return err
+	}
+
+	user, err := s.GetUserContext(ctx)
+	if err != nil {
+		return err
+	}
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"moderator_id":   {user.ID},
+		"user_id":        {userID},
+	}
+
+	err = s.requestHelper(ctx, http.MethodDelete, "/moderation/bans", queryParams, nil, nil)
+	if err != nil {
+		return fmt.Errorf("unban user: %w", err)
+	}
+	return nil
+}
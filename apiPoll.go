@@ -0,0 +1,235 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PollStatus is the status of a [Poll].
+type PollStatus string
+
+const (
+	// PollStatusActive is the status of a poll, which is running.
+	PollStatusActive PollStatus = "ACTIVE"
+	// PollStatusCompleted is the status of a poll, which reached its end time.
+	PollStatusCompleted PollStatus = "COMPLETED"
+	// PollStatusTerminated is the status of a poll, which was ended early. It is still visible on
+	// the channel.
+	PollStatusTerminated PollStatus = "TERMINATED"
+	// PollStatusArchived is the status of a poll, which was ended early and is no longer visible on
+	// the channel.
+	PollStatusArchived PollStatus = "ARCHIVED"
+	// PollStatusModerated is the status of a poll, which was deleted.
+	PollStatusModerated PollStatus = "MODERATED"
+	// PollStatusInvalid is the status of a poll, which failed.
+	PollStatusInvalid PollStatus = "INVALID"
+)
+
+type rawPollData struct {
+	// The list of polls.
+	Data       []*Poll    `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
+
+// Poll is a poll viewers can vote in.
+type Poll struct {
+	// An ID that identifies the poll.
+	ID string `json:"id"`
+	// An ID that identifies the broadcaster that created the poll.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The question that viewers are voting on.
+	Title string `json:"title"`
+	// A list of choices that viewers can choose from. The list will contain a minimum of two
+	// choices and up to a maximum of five choices.
+	Choices []*PollChoice `json:"choices"`
+
+	// Not used; will always be false.
+	BitsVotingEnabled bool `json:"bits_voting_enabled"`
+	// Not used; will always be zero.
+	BitsPerVote int `json:"bits_per_vote"`
+	// A Boolean value that indicates whether viewers may cast additional votes using Channel
+	// Points.
+	ChannelPointsVotingEnabled bool `json:"channel_points_voting_enabled"`
+	// The number of points the viewer must spend to cast one additional vote.
+	ChannelPointsPerVote int `json:"channel_points_per_vote"`
+
+	// The poll’s status.
+	Status PollStatus `json:"status"`
+	// The length of time (in seconds) that the poll will run for.
+	Duration int `json:"duration"`
+	// The UTC date and time of when the poll began.
+	StartedAt time.Time `json:"started_at"`
+	// The UTC date and time of when the poll ended. Is nil if the poll is active.
+	EndedAt *time.Time `json:"ended_at"`
+}
+
+// PollChoice is a choice of a [Poll].
+type PollChoice struct {
+	// An ID that identifies this choice.
+	ID string `json:"id"`
+	// The choice’s title.
+	Title string `json:"title"`
+	// The total number of votes cast for this choice.
+	Votes int `json:"votes"`
+	// The number of votes cast using Channel Points.
+	ChannelPointsVotes int `json:"channel_points_votes"`
+	// Not used; will always be zero.
+	BitsVotes int `json:"bits_votes"`
+}
+
+// PollParams are the settings of a poll created by [Session.CreatePoll].
+type PollParams struct {
+	// The question that viewers will vote on. The question may contain a maximum of 60 characters.
+	Title string
+	// The choices that viewers may choose from. The list must contain a minimum of 2 choices and up
+	// to a maximum of 5 choices. Each choice may contain a maximum of 25 characters.
+	Choices []string
+	// The length of time that the poll will run for. The minimum is 15 seconds and the maximum is
+	// 30 minutes.
+	Duration time.Duration
+	// A Boolean value that indicates whether viewers may cast additional votes using Channel
+	// Points.
+	ChannelPointsVotingEnabled bool
+	// The number of points that the viewer must spend to cast one additional vote. The minimum is
+	// 1 and the maximum is 1000000.
+	ChannelPointsPerVote int
+}
+
+// CreatePoll creates a poll in the channel of the given broadcaster and returns it. The current
+// session has to have the "channel:manage:polls" permission and has to be the broadcaster.
+func (s *Session) CreatePoll(broadcasterID string, params PollParams) (*Poll, error) {
+	return s.CreatePollContext(context.Background(), broadcasterID, params)
+}
+
+// CreatePollContext is like [Session.CreatePoll] but uses ctx for the API requests.
+func (s *Session) CreatePollContext(ctx context.Context, broadcasterID string, params PollParams) (*Poll, error) {
+	if err := s.requireScope("channel:manage:polls"); err != nil {
+		return nil, err
+	}
+
+	type choice struct {
+		Title string `json:"title"`
+	}
+	choices := make([]choice, len(params.Choices))
+	for i, c := range params.Choices {
+		choices[i] = choice{c}
+	}
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		BroadcasterID              string   `json:"broadcaster_id"`
+		Title                      string   `json:"title"`
+		Choices                    []choice `json:"choices"`
+		Duration                   int      `json:"duration"`
+		ChannelPointsVotingEnabled bool     `json:"channel_points_voting_enabled,omitempty"`
+		ChannelPointsPerVote       int      `json:"channel_points_per_vote,omitempty"`
+	}{
+		BroadcasterID:              broadcasterID,
+		Title:                      params.Title,
+		Choices:                    choices,
+		Duration:                   int(params.Duration.Seconds()),
+		ChannelPointsVotingEnabled: params.ChannelPointsVotingEnabled,
+		ChannelPointsPerVote:       params.ChannelPointsPerVote,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode poll: %v", err)
+	}
+
+	poll, err := s.sendPoll(ctx, http.MethodPost, body)
+	if err != nil {
+		return nil, fmt.Errorf("create poll: %w", err)
+	}
+	return poll, nil
+}
+
+// GetPolls gets the polls of the given broadcaster, the most recent first. If pollIDs are given,
+// only those polls are returned. Polls are available for 90 days after they’re created. The
+// current session has to have the "channel:read:polls" or "channel:manage:polls" permission and
+// has to be the broadcaster.
+func (s *Session) GetPolls(broadcasterID string, pollIDs ...string) ([]*Poll, error) {
+	return s.GetPollsContext(context.Background(), broadcasterID, pollIDs...)
+}
+
+// GetPollsContext is like [Session.GetPolls] but uses ctx for the API requests.
+func (s *Session) GetPollsContext(ctx context.Context, broadcasterID string, pollIDs ...string) (polls []*Poll, err error) {
+	if err := s.requireScope("channel:read:polls", "channel:manage:polls"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"first":          {"20"},
+	}
+	if len(pollIDs) > 0 {
+		queryParams["id"] = pollIDs
+	}
+
+	for {
+		var pollData rawPollData
+		err = s.requestHelper(ctx, http.MethodGet, "/polls", queryParams, nil, &pollData)
+		if err != nil {
+			return nil, fmt.Errorf("get polls: %w", err)
+		}
+		polls = append(polls, pollData.Data...)
+		if pollData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{pollData.Pagination.Cursor}
+	}
+	return polls, nil
+}
+
+// EndPoll ends the given active poll early and returns it. If archive is true, the poll is no
+// longer visible on the channel ([PollStatusArchived]), otherwise the results are still shown
+// ([PollStatusTerminated]). The current session has to have the "channel:manage:polls" permission
+// and has to be the broadcaster.
+func (s *Session) EndPoll(broadcasterID, pollID string, archive bool) (*Poll, error) {
+	return s.EndPollContext(context.Background(), broadcasterID, pollID, archive)
+}
+
+// EndPollContext is like [Session.EndPoll] but uses ctx for the API requests.
+func (s *Session) EndPollContext(ctx context.Context, broadcasterID, pollID string, archive bool) (*Poll, error) {
+	if err := s.requireScope("channel:manage:polls"); err != nil {
+		return nil, err
+	}
+
+	status := PollStatusTerminated
+	if archive {
+		status = PollStatusArchived
+	}
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		BroadcasterID string     `json:"broadcaster_id"`
+		ID            string     `json:"id"`
+		Status        PollStatus `json:"status"`
+	}{broadcasterID, pollID, status})
+	if err != nil {
+		return nil, fmt.Errorf("encode poll status: %v", err)
+	}
+
+	poll, err := s.sendPoll(ctx, http.MethodPatch, body)
+	if err != nil {
+		return nil, fmt.Errorf("end poll: %w", err)
+	}
+	return poll, nil
+}
+
+// sendPoll sends body to the polls endpoint and returns the poll from the response.
+func (s *Session) sendPoll(ctx context.Context, method string, body *bytes.Buffer) (*Poll, error) {
+	var pollData rawPollData
+	err := s.requestHelper(ctx, method, "/polls", nil, body, &pollData)
+	if err != nil {
+		return nil, err
+	}
+	if len(pollData.Data) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+	return pollData.Data[0], nil
+}
@@ -0,0 +1,252 @@
+package twitchgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PredictionStatus is the status of a [Prediction].
+type PredictionStatus string
+
+const (
+	// PredictionStatusActive is the status of a prediction, which is running and viewers can make
+	// predictions.
+	PredictionStatusActive PredictionStatus = "ACTIVE"
+	// PredictionStatusLocked is the status of a prediction, where viewers can no longer make
+	// predictions.
+	PredictionStatusLocked PredictionStatus = "LOCKED"
+	// PredictionStatusResolved is the status of a prediction, where the winning outcome was
+	// determined and the Channel Points were distributed to the viewers who predicted it.
+	PredictionStatusResolved PredictionStatus = "RESOLVED"
+	// PredictionStatusCanceled is the status of a prediction, which was canceled and the Channel
+	// Points were refunded to the participants.
+	PredictionStatusCanceled PredictionStatus = "CANCELED"
+)
+
+type rawPredictionData struct {
+	// The list of predictions.
+	Data       []*Prediction `json:"data"`
+	Pagination pagination    `json:"pagination"`
+}
+
+// Prediction is a prediction viewers can spend Channel Points on.
+type Prediction struct {
+	// An ID that identifies the prediction.
+	ID string `json:"id"`
+	// An ID that identifies the broadcaster that created the prediction.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The question that the prediction asks.
+	Title string `json:"title"`
+	// The ID of the winning outcome. Is empty unless the status is [PredictionStatusResolved].
+	WinningOutcomeID string `json:"winning_outcome_id"`
+	// The list of possible outcomes for the prediction.
+	Outcomes []*PredictionOutcome `json:"outcomes"`
+	// The length of time (in seconds) that the prediction will run for.
+	PredictionWindow int `json:"prediction_window"`
+	// The prediction’s status.
+	Status PredictionStatus `json:"status"`
+
+	// The UTC date and time of when the prediction began.
+	CreatedAt time.Time `json:"created_at"`
+	// The UTC date and time of when the prediction ended. Is nil if the prediction is active or
+	// locked.
+	EndedAt *time.Time `json:"ended_at"`
+	// The UTC date and time of when the prediction was locked. Is nil if the prediction was not
+	// locked.
+	LockedAt *time.Time `json:"locked_at"`
+}
+
+// PredictionOutcome is a possible outcome of a [Prediction].
+type PredictionOutcome struct {
+	// An ID that identifies this outcome.
+	ID string `json:"id"`
+	// The outcome’s text.
+	Title string `json:"title"`
+	// The number of unique viewers that chose this outcome.
+	Users int `json:"users"`
+	// The number of Channel Points spent by viewers on this outcome.
+	ChannelPoints int `json:"channel_points"`
+	// A list of viewers who were the top predictors.
+	TopPredictors []*PredictionPredictor `json:"top_predictors"`
+	// The color that visually identifies this outcome in the UX. Possible values are:
+	//
+	//  "BLUE" // The first outcome
+	//  "PINK" // All other outcomes
+	Color string `json:"color"`
+}
+
+// PredictionPredictor is a viewer who spent Channel Points on a [PredictionOutcome].
+type PredictionPredictor struct {
+	userReference
+	// The number of Channel Points the viewer spent.
+	ChannelPointsUsed int `json:"channel_points_used"`
+	// The number of Channel Points distributed to the viewer.
+	ChannelPointsWon int `json:"channel_points_won"`
+}
+
+// PredictionParams are the settings of a prediction created by [Session.CreatePrediction].
+type PredictionParams struct {
+	// The question that the broadcaster is asking. The title is limited to a maximum of 45
+	// characters.
+	Title string
+	// The list of possible outcomes that the viewers may choose from. The list must contain a
+	// minimum of 2 choices and up to a maximum of 10 choices. Each outcome is limited to a maximum
+	// of 25 characters.
+	Outcomes []string
+	// The length of time that the viewers have to make their predictions. The minimum is 30
+	// seconds and the maximum is 30 minutes.
+	PredictionWindow time.Duration
+}
+
+// CreatePrediction creates a Channel Points prediction in the channel of the given broadcaster
+// and returns it. The current session has to have the "channel:manage:predictions" permission and
+// has to be the broadcaster.
+func (s *Session) CreatePrediction(broadcasterID string, params PredictionParams) (*Prediction, error) {
+	return s.CreatePredictionContext(context.Background(), broadcasterID, params)
+}
+
+// CreatePredictionContext is like [Session.CreatePrediction] but uses ctx for the API requests.
+func (s *Session) CreatePredictionContext(ctx context.Context, broadcasterID string, params PredictionParams) (*Prediction, error) {
+	if err := s.requireScope("channel:manage:predictions"); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		Title string `json:"title"`
+	}
+	outcomes := make([]outcome, len(params.Outcomes))
+	for i, o := range params.Outcomes {
+		outcomes[i] = outcome{o}
+	}
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		BroadcasterID    string    `json:"broadcaster_id"`
+		Title            string    `json:"title"`
+		Outcomes         []outcome `json:"outcomes"`
+		PredictionWindow int       `json:"prediction_window"`
+	}{
+		BroadcasterID:    broadcasterID,
+		Title:            params.Title,
+		Outcomes:         outcomes,
+		PredictionWindow: int(params.PredictionWindow.Seconds()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode prediction: %v", err)
+	}
+
+	prediction, err := s.sendPrediction(ctx, http.MethodPost, body)
+	if err != nil {
+		return nil, fmt.Errorf("create prediction: %w", err)
+	}
+	return prediction, nil
+}
+
+// GetPredictions gets the predictions of the given broadcaster, the most recent first. If
+// predictionIDs are given, only those predictions are returned. The current session has to have
+// the "channel:read:predictions" or "channel:manage:predictions" permission and has to be the
+// broadcaster.
+func (s *Session) GetPredictions(broadcasterID string, predictionIDs ...string) ([]*Prediction, error) {
+	return s.GetPredictionsContext(context.Background(), broadcasterID, predictionIDs...)
+}
+
+// GetPredictionsContext is like [Session.GetPredictions] but uses ctx for the API requests.
+func (s *Session) GetPredictionsContext(ctx context.Context, broadcasterID string, predictionIDs ...string) (predictions []*Prediction, err error) {
+	if err := s.requireScope("channel:read:predictions", "channel:manage:predictions"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"first":          {"25"},
+	}
+	if len(predictionIDs) > 0 {
+		queryParams["id"] = predictionIDs
+	}
+
+	for {
+		var predictionData rawPredictionData
+		err = s.requestHelper(ctx, http.MethodGet, "/predictions", queryParams, nil, &predictionData)
+		if err != nil {
+			return nil, fmt.Errorf("get predictions: %w", err)
+		}
+		predictions = append(predictions, predictionData.Data...)
+		if predictionData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{predictionData.Pagination.Cursor}
+	}
+	return predictions, nil
+}
+
+// EndPrediction locks, resolves or cancels the given prediction and returns it. The status has to
+// be one of:
+//
+//   - [PredictionStatusLocked] to stop viewers from making predictions. The prediction has to be
+//     resolved or canceled later.
+//   - [PredictionStatusResolved] to end the prediction with winningOutcomeID as the winner. The
+//     Channel Points are distributed to the viewers who predicted that outcome.
+//   - [PredictionStatusCanceled] to end the prediction without a winner. The Channel Points are
+//     refunded to the participants.
+//
+// winningOutcomeID is only used, when resolving the prediction. The current session has to have
+// the "channel:manage:predictions" permission and has to be the broadcaster.
+func (s *Session) EndPrediction(broadcasterID, predictionID string, status PredictionStatus, winningOutcomeID string) (*Prediction, error) {
+	return s.EndPredictionContext(context.Background(), broadcasterID, predictionID, status, winningOutcomeID)
+}
+
+// EndPredictionContext is like [Session.EndPrediction] but uses ctx for the API requests.
+func (s *Session) EndPredictionContext(ctx context.Context, broadcasterID, predictionID string, status PredictionStatus, winningOutcomeID string) (*Prediction, error) {
+	if err := s.requireScope("channel:manage:predictions"); err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case PredictionStatusResolved:
+		if winningOutcomeID == "" {
+			return nil, fmt.Errorf("end prediction: resolving requires a winning outcome")
+		}
+	case PredictionStatusLocked, PredictionStatusCanceled:
+		winningOutcomeID = ""
+	default:
+		return nil, fmt.Errorf("end prediction: invalid status '%s'", status)
+	}
+
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		BroadcasterID    string           `json:"broadcaster_id"`
+		ID               string           `json:"id"`
+		Status           PredictionStatus `json:"status"`
+		WinningOutcomeID string           `json:"winning_outcome_id,omitempty"`
+	}{broadcasterID, predictionID, status, winningOutcomeID})
+	if err != nil {
+		return nil, fmt.Errorf("encode prediction status: %v", err)
+	}
+
+	prediction, err := s.sendPrediction(ctx, http.MethodPatch, body)
+	if err != nil {
+		return nil, fmt.Errorf("end prediction: %w", err)
+	}
+	return prediction, nil
+}
+
+// sendPrediction sends body to the predictions endpoint and returns the prediction from the
+// response.
+func (s *Session) sendPrediction(ctx context.Context, method string, body *bytes.Buffer) (*Prediction, error) {
+	var predictionData rawPredictionData
+	err := s.requestHelper(ctx, method, "/predictions", nil, body, &predictionData)
+	if err != nil {
+		return nil, err
+	}
+	if len(predictionData.Data) == 0 {
+		return nil, fmt.Errorf("empty response")
+	}
+	return predictionData.Data[0], nil
+}
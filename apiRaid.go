@@ -0,0 +1,116 @@
+package twitchgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrRaidInProgress is returned by [Session.StartRaid] when the broadcaster is already raiding
+	// another channel.
+	ErrRaidInProgress = errors.New("broadcaster is already raiding")
+
+	// ErrRaidTargetNotFound is returned by [Session.StartRaid] when the channel to raid does not
+	// exist or does not accept raids from the broadcaster.
+	ErrRaidTargetNotFound = errors.New("raid target not found")
+
+	// ErrNoPendingRaid is returned by [Session.CancelRaid] when the broadcaster has no pending raid
+	// to cancel, e.g. because the raid already happened.
+	ErrNoPendingRaid = errors.New("broadcaster has no pending raid")
+
+	// ErrRaidRateLimited is returned by [Session.StartRaid] and [Session.CancelRaid] when the
+	// broadcaster exceeded the 10 raid requests allowed within a 10-minute window.
+	ErrRaidRateLimited = errors.New("too many raid requests within 10 minutes")
+)
+
+type rawRaidData struct {
+	// A list that contains a single object with information about the pending raid.
+	Data []*RaidResult `json:"data"`
+}
+
+// RaidResult is the pending raid started by [Session.StartRaid].
+type RaidResult struct {
+	// The UTC date and time of when the raid was requested.
+	CreatedAt time.Time `json:"created_at"`
+	// A Boolean value that indicates whether the channel being raided contains mature content.
+	IsMature bool `json:"is_mature"`
+}
+
+// StartRaid raids another channel by sending the broadcaster’s viewers to the targeted channel.
+// The raid does not happen immediately. Twitch shows a countdown in the chat of the raiding
+// broadcaster and the raid happens when the countdown expires or the broadcaster clicks
+// "Raid Now". Until then, the raid can be canceled with [Session.CancelRaid]. The current session
+// has to have the "channel:manage:raids" permission and has to be the raiding broadcaster.
+//
+// Returns [ErrRaidInProgress] if the broadcaster is already raiding, [ErrRaidTargetNotFound] if
+// the targeted channel was not found and [ErrRaidRateLimited] if too many raids were requested in
+// a short period of time.
+func (s *Session) StartRaid(fromBroadcasterID, toBroadcasterID string) (*RaidResult, error) {
+	return s.StartRaidContext(context.Background(), fromBroadcasterID, toBroadcasterID)
+}
+
+// StartRaidContext is like [Session.StartRaid] but uses ctx for the API requests.
+func (s *Session) StartRaidContext(ctx context.Context, fromBroadcasterID, toBroadcasterID string) (*RaidResult, error) {
+	if err := s.requireScope("channel:manage:raids"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"from_broadcaster_id": {fromBroadcasterID},
+		"to_broadcaster_id":   {toBroadcasterID},
+	}
+
+	var raidData rawRaidData
+	err := s.requestHelper(ctx, http.MethodPost, "/raids", queryParams, nil, &raidData)
+	switch statusCode(err) {
+	case 0:
+	case http.StatusConflict:
+		return nil, ErrRaidInProgress
+	case http.StatusNotFound:
+		return nil, ErrRaidTargetNotFound
+	case http.StatusTooManyRequests:
+		return nil, ErrRaidRateLimited
+	default:
+		return nil, fmt.Errorf("start raid: %w", err)
+	}
+	if len(raidData.Data) == 0 {
+		return nil, fmt.Errorf("start raid: empty response")
+	}
+	return raidData.Data[0], nil
+}
+
+// CancelRaid cancels the pending raid of the broadcaster, that was started with
+// [Session.StartRaid]. The current session has to have the "channel:manage:raids" permission and
+// has to be the broadcaster.
+//
+// Returns [ErrNoPendingRaid] if there is no pending raid and [ErrRaidRateLimited] if too many
+// raids were requested in a short period of time.
+func (s *Session) CancelRaid(broadcasterID string) error {
+	return s.CancelRaidContext(context.Background(), broadcasterID)
+}
+
+// CancelRaidContext is like [Session.CancelRaid] but uses ctx for the API requests.
+func (s *Session) CancelRaidContext(ctx context.Context, broadcasterID string) error {
+	if err := s.requireScope("channel:manage:raids"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+	}
+
+	err := s.requestHelper(ctx, http.MethodDelete, "/raids", queryParams, nil, nil)
+	switch statusCode(err) {
+	case 0:
+		return nil
+	case http.StatusNotFound:
+		return ErrNoPendingRaid
+	case http.StatusTooManyRequests:
+		return ErrRaidRateLimited
+	default:
+		return fmt.Errorf("cancel raid: %w", err)
+	}
+}
@@ -0,0 +1,126 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// scheduleSegmentsPerPage is the maximum number of segments Twitch returns per request.
+const scheduleSegmentsPerPage = 25
+
+type rawScheduleData struct {
+	// The broadcaster’s streaming schedule.
+	Data       *Schedule  `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
+
+// ScheduleParams are the filters used by [Session.GetStreamSchedule]. Empty fields are ignored.
+type ScheduleParams struct {
+	// The IDs of the scheduled segments to get. Up to 100 IDs.
+	IDs []string
+	// The date and time of the first segment to get. Defaults to the current date and time.
+	StartTime time.Time
+	// The maximum number of segments to get. If 0, the first 25 segments are returned. Recurring
+	// segments repeat indefinitely, so there is no option to get all segments.
+	Limit int
+}
+
+// Schedule is the streaming schedule of a broadcaster.
+type Schedule struct {
+	// The list of broadcasts in the broadcaster’s streaming schedule.
+	Segments []*ScheduleSegment `json:"segments"`
+	// The ID of the broadcaster that owns the broadcast schedule.
+	BroadcasterID string `json:"broadcaster_id"`
+	// The broadcaster’s display name.
+	BroadcasterName string `json:"broadcaster_name"`
+	// The broadcaster’s login name.
+	BroadcasterLogin string `json:"broadcaster_login"`
+	// The dates when the broadcaster is on vacation and not streaming. Is nil if vacation mode is
+	// not enabled.
+	Vacation *struct {
+		// The UTC date and time of when the broadcaster’s vacation starts.
+		StartTime time.Time `json:"start_time"`
+		// The UTC date and time of when the broadcaster’s vacation ends.
+		EndTime time.Time `json:"end_time"`
+	} `json:"vacation"`
+}
+
+// ScheduleSegment is a scheduled broadcast in a [Schedule].
+type ScheduleSegment struct {
+	// An ID that identifies this broadcast segment.
+	ID string `json:"id"`
+	// The UTC date and time of when the broadcast starts.
+	StartTime time.Time `json:"start_time"`
+	// The UTC date and time of when the broadcast ends.
+	EndTime time.Time `json:"end_time"`
+	// The broadcast segment’s title.
+	Title string `json:"title"`
+	// Indicates whether the broadcaster canceled this segment of a recurring broadcast. If the
+	// broadcaster canceled this segment, CanceledUntil is set to the same value that’s in EndTime;
+	// otherwise, it’s nil.
+	CanceledUntil *time.Time `json:"canceled_until"`
+	// The type of content that the broadcaster plans to stream. Is nil if not specified.
+	Category *struct {
+		// An ID that identifies the category that best represents the content.
+		ID string `json:"id"`
+		// The name of the category.
+		Name string `json:"name"`
+	} `json:"category"`
+	// A Boolean value that determines whether the broadcast is part of a recurring series.
+	IsRecurring bool `json:"is_recurring"`
+}
+
+// GetStreamSchedule gets the streaming schedule of the given broadcaster, matching the given
+// filters.
+func (s *Session) GetStreamSchedule(broadcasterID string, params ScheduleParams) (*Schedule, error) {
+	return s.GetStreamScheduleContext(context.Background(), broadcasterID, params)
+}
+
+// GetStreamScheduleContext is like [Session.GetStreamSchedule] but uses ctx for the API requests.
+func (s *Session) GetStreamScheduleContext(ctx context.Context, broadcasterID string, params ScheduleParams) (*Schedule, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = scheduleSegmentsPerPage
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"first":          {strconv.Itoa(min(limit, scheduleSegmentsPerPage))},
+	}
+	if len(params.IDs) > 0 {
+		queryParams["id"] = params.IDs
+	}
+	if !params.StartTime.IsZero() {
+		queryParams["start_time"] = []string{params.StartTime.UTC().Format(time.RFC3339)}
+	}
+
+	var schedule *Schedule
+	for {
+		var scheduleData rawScheduleData
+		err := s.requestHelper(ctx, http.MethodGet, "/schedule", queryParams, nil, &scheduleData)
+		if err != nil {
+			return nil, fmt.Errorf("get stream schedule: %w", err)
+		}
+		if scheduleData.Data == nil {
+			return nil, fmt.Errorf("get stream schedule: empty response")
+		}
+		if schedule == nil {
+			schedule = scheduleData.Data
+		} else {
+			schedule.Segments = append(schedule.Segments, scheduleData.Data.Segments...)
+		}
+
+		if len(schedule.Segments) >= limit {
+			schedule.Segments = schedule.Segments[:limit]
+			break
+		}
+		if scheduleData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{scheduleData.Pagination.Cursor}
+	}
+	return schedule, nil
+}
@@ -1,16 +1,37 @@
 package twitchgo
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type rawStreamData struct {
 	// The list of streams.
-	Data []*Stream `json:"data"`
+	Data       []*Stream  `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
 
-	pagination
+// StreamParams are the filters used by [Session.GetStreams]. Empty fields are ignored. Streams
+// matching any of the given values of a filter are returned.
+type StreamParams struct {
+	// The IDs of the users to get streams for. Up to 100 IDs combined with UserLogins.
+	UserIDs []string
+	// The login names of the users to get streams for. Up to 100 names combined with UserIDs.
+	UserLogins []string
+	// The IDs of the categories or games to get streams for. Up to 100 IDs.
+	GameIDs []string
+	// The type of stream to filter by. Possible values are:
+	//	"all" // default
+	//	"live"
+	Type string
+	// The ISO 639-1 two-letter language codes or "other" to filter by. Up to 100 languages.
+	Languages []string
 }
 
 // Stream represents a twitch live stream with all its informations.
@@ -33,7 +54,7 @@ type Stream struct {
 	// The type of stream. Possible values are:
 	//  "live" //
 	// If an error occurs, this field is set to an empty string.
-	Type string `json:"Type"`
+	Type string `json:"type"`
 
 	// The stream’s title. Is an empty string if not set.
 	Title string `json:"title"`
@@ -55,42 +76,277 @@ type Stream struct {
 	IsMature bool `json:"is_mature"`
 }
 
+// GetStreams gets all the streams matching the given filters, sorted by number of viewers in
+// descending order. It pages through all results, so be careful with wide filters.
+func (s *Session) GetStreams(params StreamParams) ([]*Stream, error) {
+	return s.GetStreamsContext(context.Background(), params)
+}
+
+// GetStreamsContext is like [Session.GetStreams] but uses ctx for the API requests.
+func (s *Session) GetStreamsContext(ctx context.Context, params StreamParams) (streams []*Stream, err error) {
+	queryParams := map[string][]string{
+		"first": {"100"},
+	}
+	if len(params.UserIDs) > 0 {
+		queryParams["user_id"] = params.UserIDs
+	}
+	if len(params.UserLogins) > 0 {
+		queryParams["user_login"] = params.UserLogins
+	}
+	if len(params.GameIDs) > 0 {
+		queryParams["game_id"] = params.GameIDs
+	}
+	if params.Type != "" {
+		queryParams["type"] = []string{params.Type}
+	}
+	if len(params.Languages) > 0 {
+		queryParams["language"] = params.Languages
+	}
+
+	for {
+		var streamData rawStreamData
+		err = s.requestHelper(ctx, http.MethodGet, "/streams", queryParams, nil, &streamData)
+		if err != nil {
+			return nil, fmt.Errorf("get streams: %w", err)
+		}
+		streams = append(streams, streamData.Data...)
+		if streamData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{streamData.Pagination.Cursor}
+	}
+	return streams, nil
+}
+
 // GetStreamsByID gets all the streams matching the given user IDs of the streamers.
-// Returns only the streams of those users that are broadcasting.
+// Returns only the streams of those users that are broadcasting. More than 100 IDs are split into
+// multiple requests.
 func (s *Session) GetStreamsByID(userIDs ...string) ([]*Stream, error) {
+	return s.GetStreamsByIDContext(context.Background(), userIDs...)
+}
+
+// GetStreamsByIDContext is like [Session.GetStreamsByID] but uses ctx for the API requests.
+func (s *Session) GetStreamsByIDContext(ctx context.Context, userIDs ...string) ([]*Stream, error) {
 	if len(userIDs) == 0 {
 		return []*Stream{}, nil
 	}
-	queryParams := map[string][]string{
-		"user_id": userIDs,
-		"first":   {"100"},
-	}
 
-	var streamData rawStreamData
-	err := s.requestHelper(http.MethodGet, "/streams", queryParams, nil, &streamData)
-	if err != nil {
-		return []*Stream{}, fmt.Errorf("get streams by id: %v", err)
+	var streams []*Stream
+	for _, batch := range chunk(userIDs, maxIDsPerRequest) {
+		batchStreams, err := s.GetStreamsContext(ctx, StreamParams{UserIDs: batch})
+		if err != nil {
+			return []*Stream{}, fmt.Errorf("get streams by id: %w", err)
+		}
+		streams = append(streams, batchStreams...)
 	}
-
-	return streamData.Data, nil
+	return streams, nil
 }
 
 // GetStreamsByName gets all the streams matching the given user login names of the streamers.
-// Returns only the streams of those users that are broadcasting.
+// Returns only the streams of those users that are broadcasting. More than 100 names are split
+// into multiple requests.
 func (s *Session) GetStreamsByName(userLoginNames ...string) ([]*Stream, error) {
+	return s.GetStreamsByNameContext(context.Background(), userLoginNames...)
+}
+
+// GetStreamsByNameContext is like [Session.GetStreamsByName] but uses ctx for the API requests.
+func (s *Session) GetStreamsByNameContext(ctx context.Context, userLoginNames ...string) ([]*Stream, error) {
 	if len(userLoginNames) == 0 {
 		return []*Stream{}, nil
 	}
-	queryParams := map[string][]string{
-		"user_login": userLoginNames,
-		"first":      {"100"},
+
+	var streams []*Stream
+	for _, batch := range chunk(userLoginNames, maxIDsPerRequest) {
+		batchStreams, err := s.GetStreamsContext(ctx, StreamParams{UserLogins: batch})
+		if err != nil {
+			return []*Stream{}, fmt.Errorf("get streams by name: %w", err)
+		}
+		streams = append(streams, batchStreams...)
+	}
+	return streams, nil
+}
+
+// IsStreamLive reports whether the user with the given login name is broadcasting. If so, the
+// stream is returned as well.
+func (s *Session) IsStreamLive(login string) (bool, *Stream, error) {
+	return s.IsStreamLiveContext(context.Background(), login)
+}
+
+// IsStreamLiveContext is like [Session.IsStreamLive] but uses ctx for the API requests.
+func (s *Session) IsStreamLiveContext(ctx context.Context, login string) (bool, *Stream, error) {
+	streams, err := s.GetStreamsByNameContext(ctx, login)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(streams) == 0 {
+		return false, nil, nil
+	}
+	return true, streams[0], nil
+}
+
+// WatchStreams polls the streams of the given users every interval and calls onOnline when one of
+// them starts streaming and onOffline when one of them stops streaming. This is a simple
+// alternative to EventSub. onOffline receives the stream as it was seen last. Either callback may
+// be nil.
+//
+// The first poll happens immediately and calls onOnline for every user, who is already live. If a
+// user starts a new stream between two polls, onOffline is called for the old stream and onOnline
+// for the new one. Errors while polling are logged and the next poll is tried as usual, without
+// reporting any changes. WatchStreams returns a function to stop polling.
+//
+// Intervals shorter than 1 second are raised to 1 second.
+func (s *Session) WatchStreams(interval time.Duration, logins []string, onOnline, onOffline func(*Stream)) (stop func()) {
+	interval = max(interval, minPollInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		live := make(map[string]*Stream)
+		for {
+			streams, err := s.GetStreamsByNameContext(ctx, logins...)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Failed to poll streams: %v", err)
+			} else if err == nil {
+				live = diffStreams(live, streams, onOnline, onOffline)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// diffStreams calls onOnline and onOffline for the differences between the previously live
+// streams and the currently live streams. It returns the current streams keyed by login.
+func diffStreams(previous map[string]*Stream, streams []*Stream, onOnline, onOffline func(*Stream)) map[string]*Stream {
+	current := make(map[string]*Stream, len(streams))
+	for _, stream := range streams {
+		current[strings.ToLower(stream.UserLogin)] = stream
+	}
+
+	for login, old := range previous {
+		if stream, ok := current[login]; (!ok || stream.ID != old.ID) && onOffline != nil {
+			onOffline(old)
+		}
+	}
+	for login, stream := range current {
+		if old, ok := previous[login]; (!ok || stream.ID != old.ID) && onOnline != nil {
+			onOnline(stream)
+		}
+	}
+	return current
+}
+
+// StreamMarker is a marker of a moment in a stream, which editors can jump to in the video of the
+// stream.
+type StreamMarker struct {
+	// An ID that identifies this marker.
+	ID string `json:"id"`
+	// The UTC date and time (in RFC3339 format) of when the user created the marker.
+	CreatedAt time.Time `json:"created_at"`
+	// The description that the user gave the marker to help them remember why they marked the
+	// location. Is an empty string if not set.
+	Description string `json:"description"`
+	// The relative offset (in seconds) of the marker from the beginning of the stream.
+	PositionSeconds int `json:"position_seconds"`
+	// A URL that opens the video in Twitch Highlighter. Only set by [Session.GetStreamMarkers].
+	URL string `json:"url"`
+	// The ID of the video the marker belongs to. Only set by [Session.GetStreamMarkers].
+	VideoID string `json:"-"`
+}
+
+// CreateStreamMarker adds a marker to the live stream of the given user at the current position
+// and returns it. description is optional and may be up to 140 characters long. The current
+// session has to have the "channel:manage:broadcast" permission and has to be the broadcaster or
+// one of their editors.
+func (s *Session) CreateStreamMarker(userID, description string) (*StreamMarker, error) {
+	return s.CreateStreamMarkerContext(context.Background(), userID, description)
+}
+
+// CreateStreamMarkerContext is like [Session.CreateStreamMarker] but uses ctx for the API
+// requests.
+func (s *Session) CreateStreamMarkerContext(ctx context.Context, userID, description string) (*StreamMarker, error) {
+	if err := s.requireScope("channel:manage:broadcast"); err != nil {
+		return nil, err
 	}
 
-	var streamData rawStreamData
-	err := s.requestHelper(http.MethodGet, "/streams", queryParams, nil, &streamData)
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(struct {
+		UserID      string `json:"user_id"`
+		Description string `json:"description,omitempty"`
+	}{userID, description})
 	if err != nil {
-		return []*Stream{}, fmt.Errorf("get streams by name: %v", err)
+		return nil, fmt.Errorf("encode stream marker: %v", err)
 	}
 
-	return streamData.Data, nil
+	var markerData struct {
+		Data []*StreamMarker `json:"data"`
+	}
+	err = s.requestHelper(ctx, http.MethodPost, "/streams/markers", nil, body, &markerData)
+	if err != nil {
+		return nil, fmt.Errorf("create stream marker: %w", err)
+	}
+	if len(markerData.Data) == 0 {
+		return nil, fmt.Errorf("create stream marker: empty response")
+	}
+	return markerData.Data[0], nil
+}
+
+type rawStreamMarkerData struct {
+	// The list of users with their videos and markers.
+	Data []struct {
+		Videos []struct {
+			// An ID that identifies this video.
+			VideoID string `json:"video_id"`
+			// The list of markers in this video.
+			Markers []*StreamMarker `json:"markers"`
+		} `json:"videos"`
+	} `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
+
+// GetStreamMarkers gets the markers of the most recent stream of the given user. The current
+// session has to have the "user:read:broadcast" or "channel:manage:broadcast" permission and has
+// to be the broadcaster or one of their editors.
+func (s *Session) GetStreamMarkers(userID string) ([]*StreamMarker, error) {
+	return s.GetStreamMarkersContext(context.Background(), userID)
+}
+
+// GetStreamMarkersContext is like [Session.GetStreamMarkers] but uses ctx for the API requests.
+func (s *Session) GetStreamMarkersContext(ctx context.Context, userID string) (markers []*StreamMarker, err error) {
+	if err := s.requireScope("user:read:broadcast", "channel:manage:broadcast"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"user_id": {userID},
+		"first":   {"100"},
+	}
+
+	for {
+		var markerData rawStreamMarkerData
+		err = s.requestHelper(ctx, http.MethodGet, "/streams/markers", queryParams, nil, &markerData)
+		if err != nil {
+			return nil, fmt.Errorf("get stream markers: %w", err)
+		}
+		for _, user := range markerData.Data {
+			for _, video := range user.Videos {
+				for _, marker := range video.Markers {
+					marker.VideoID = video.VideoID
+					markers = append(markers, marker)
+				}
+			}
+		}
+		if markerData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{markerData.Pagination.Cursor}
+	}
+	return markers, nil
 }
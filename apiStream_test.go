@@ -0,0 +1,40 @@
+package twitchgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStreamUnmarshal(t *testing.T) {
+	// sample response of https://dev.twitch.tv/docs/api/reference/#get-streams
+	const response = `{
+		"data": [{
+			"id": "40952121085",
+			"user_id": "101051819",
+			"user_login": "afro",
+			"user_name": "Afro",
+			"game_id": "32982",
+			"game_name": "Grand Theft Auto V",
+			"type": "live",
+			"title": "Jacob: Digital Den Laptops & Routers | NoPixel | !MAINGEAR !FCF",
+			"tags": ["English"],
+			"viewer_count": 1490,
+			"started_at": "2021-03-10T03:18:11Z",
+			"language": "en",
+			"thumbnail_url": "https://static-cdn.jtvnw.net/previews-ttv/live_user_afro-{width}x{height}.jpg",
+			"is_mature": false
+		}],
+		"pagination": {"cursor": "eyJiIjp7IkN1cnNvciI6ImV5SnpJam8zT0RNMk5TNDBORFF4TlRjMU1UY3hOU3dpWkNJNlptRnNjMlVzSW5RaU9uUnlkV1Y5In0sImEiOnsiQ3Vyc29yIjoiZXlKeklqb3hOVGs0TkM0MU56RXhNekExTVRZNU1ESXNJbVFpT21aaGJITmxMQ0owSWpwMGNuVmxmUT09In19"}
+	}`
+
+	var streamData rawStreamData
+	if err := json.Unmarshal([]byte(response), &streamData); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(streamData.Data) != 1 {
+		t.Fatalf("got %d streams, want 1", len(streamData.Data))
+	}
+	if stream := streamData.Data[0]; stream.Type != "live" {
+		t.Errorf("Type = %q, want %q", stream.Type, "live")
+	}
+}
@@ -1,16 +1,148 @@
 package twitchgo
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 )
 
+// ErrUserNotFound is returned when looking up a single user that does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// userIDCache caches the IDs of users by their login name.
+type userIDCache struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func (c *userIDCache) get(login string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.ids[login]
+	return id, ok
+}
+
+func (c *userIDCache) set(login, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ids == nil {
+		c.ids = make(map[string]string)
+	}
+	c.ids[login] = id
+}
+
+// userCache caches users by their ID and login name for a limited time, see
+// [Session.SetUserCacheTTL].
+type userCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	byID    map[string]cachedUser
+	byLogin map[string]cachedUser
+}
+
+type cachedUser struct {
+	user    User
+	expires time.Time
+}
+
+// get returns the cached user with the given key, from byID or byLogin. Expired users are removed.
+func (c *userCache) get(users map[string]cachedUser, key string) (*User, bool) {
+	entry, ok := users[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(users, key)
+		return nil, false
+	}
+	user := entry.user
+	return &user, true
+}
+
+// lookup splits keys into the cached users and the keys missing in the cache. byLogin selects
+// whether keys are login names or IDs.
+func (c *userCache) lookup(keys []string, byLogin bool) (users []*User, missing []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return nil, keys
+	}
+	cached := c.byID
+	if byLogin {
+		cached = c.byLogin
+	}
+	for _, key := range keys {
+		if user, ok := c.get(cached, key); ok {
+			users = append(users, user)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	return users, missing
+}
+
+// add caches the given users, if caching is enabled.
+func (c *userCache) add(users []*User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	if c.byID == nil {
+		c.byID = make(map[string]cachedUser)
+		c.byLogin = make(map[string]cachedUser)
+	}
+	expires := time.Now().Add(c.ttl)
+	for _, user := range users {
+		entry := cachedUser{user: *user, expires: expires}
+		c.byID[user.ID] = entry
+		c.byLogin[user.Login] = entry
+	}
+}
+
+// SetUserCacheTTL enables caching the users returned by [Session.GetUsersByID] and
+// [Session.GetUsersByName]. Users requested again within ttl are returned from the cache without
+// an API request. Setting ttl to 0 disables the cache and forgets all cached users, which is the
+// default.
+func (s *Session) SetUserCacheTTL(ttl time.Duration) *Session {
+	s.users.mu.Lock()
+	defer s.users.mu.Unlock()
+	s.users.ttl = ttl
+	s.users.byID = nil
+	s.users.byLogin = nil
+	return s
+}
+
 type rawUserData struct {
 	// 	The list of users.
 	Data []*User `json:"data"`
 }
 
+// userReference is the short form of a user many endpoints respond with.
+type userReference struct {
+	// An ID that identifies the user.
+	UserID string `json:"user_id"`
+	// The user’s login name.
+	UserLogin string `json:"user_login"`
+	// The user’s display name.
+	UserName string `json:"user_name"`
+}
+
+// user converts the reference into a User with only the ID, Login and DisplayName set.
+func (u userReference) user() *User {
+	return &User{
+		ID:          u.UserID,
+		Login:       u.UserLogin,
+		DisplayName: u.UserName,
+	}
+}
+
 // User represents a twitch user account with all its informations.
 type User struct {
 	// An ID that identifies the user.
@@ -26,7 +158,7 @@ type User struct {
 	//  "global_mod"
 	//  "staff"      // Twitch staff
 	//  ""           // Normal user
-	Type string `json:"Type"`
+	Type string `json:"type"`
 	// The type of broadcaster. Possible values are:
 	//
 	//  "affiliate" // An affiliate broadcaster
@@ -55,48 +187,107 @@ type User struct {
 
 // GetUser returns the Twitch user in the current access token.
 func (s *Session) GetUser() (*User, error) {
+	return s.GetUserContext(context.Background())
+}
+
+// GetUserContext is like [Session.GetUser] but uses ctx for the API requests.
+func (s *Session) GetUserContext(ctx context.Context) (*User, error) {
 
 	var userData rawUserData
-	err := s.requestHelper(http.MethodGet, "/users", nil, nil, &userData)
+	err := s.requestHelper(ctx, http.MethodGet, "/users", nil, nil, &userData)
 	if err != nil {
-		return &User{}, fmt.Errorf("get logged in users: %v", err)
+		return &User{}, fmt.Errorf("get logged in users: %w", err)
 	}
 
 	return userData.Data[0], nil
 }
 
-// GetUsersByID gets all the Twitch users matching the given user IDs.
+// GetUsersByID gets all the Twitch users matching the given user IDs. More than 100 IDs are split
+// into multiple requests. Cached users are not requested again, see [Session.SetUserCacheTTL].
 func (s *Session) GetUsersByID(userIDs ...string) ([]*User, error) {
+	return s.GetUsersByIDContext(context.Background(), userIDs...)
+}
+
+// GetUsersByIDContext is like [Session.GetUsersByID] but uses ctx for the API requests.
+func (s *Session) GetUsersByIDContext(ctx context.Context, userIDs ...string) ([]*User, error) {
 	if len(userIDs) == 0 {
 		return []*User{}, nil
 	}
-	queryParams := map[string][]string{
-		"id": userIDs,
-	}
+	users, userIDs := s.users.lookup(userIDs, false)
+	for _, batch := range chunk(userIDs, maxIDsPerRequest) {
+		queryParams := map[string][]string{
+			"id": batch,
+		}
 
-	var streamData rawUserData
-	err := s.requestHelper(http.MethodGet, "/users", queryParams, nil, &streamData)
-	if err != nil {
-		return []*User{}, fmt.Errorf("get users by id: %v", err)
+		var userData rawUserData
+		err := s.requestHelper(ctx, http.MethodGet, "/users", queryParams, nil, &userData)
+		if err != nil {
+			return []*User{}, fmt.Errorf("get users by id: %w", err)
+		}
+		s.users.add(userData.Data)
+		users = append(users, userData.Data...)
 	}
 
-	return streamData.Data, nil
+	return users, nil
 }
 
-// GetUsersByName gets all the Twitch users matching the given user login names.
+// GetUsersByName gets all the Twitch users matching the given user login names. More than 100
+// names are split into multiple requests. Cached users are not requested again, see
+// [Session.SetUserCacheTTL].
 func (s *Session) GetUsersByName(userLoginNames ...string) ([]*User, error) {
+	return s.GetUsersByNameContext(context.Background(), userLoginNames...)
+}
+
+// GetUsersByNameContext is like [Session.GetUsersByName] but uses ctx for the API requests.
+func (s *Session) GetUsersByNameContext(ctx context.Context, userLoginNames ...string) ([]*User, error) {
 	if len(userLoginNames) == 0 {
 		return []*User{}, nil
 	}
-	queryParams := map[string][]string{
-		"login": userLoginNames,
+	logins := make([]string, len(userLoginNames))
+	for i, login := range userLoginNames {
+		logins[i] = strings.ToLower(login)
 	}
+	users, logins := s.users.lookup(logins, true)
+	for _, batch := range chunk(logins, maxIDsPerRequest) {
+		queryParams := map[string][]string{
+			"login": batch,
+		}
 
-	var streamData rawUserData
-	err := s.requestHelper(http.MethodGet, "/users", queryParams, nil, &streamData)
+		var userData rawUserData
+		err := s.requestHelper(ctx, http.MethodGet, "/users", queryParams, nil, &userData)
+		if err != nil {
+			return []*User{}, fmt.Errorf("get users by name: %w", err)
+		}
+		s.users.add(userData.Data)
+		users = append(users, userData.Data...)
+	}
+
+	return users, nil
+}
+
+// GetUserID returns the ID of the user with the given login name. The IDs are cached, so only the
+// first call for a login name makes a request to the Twitch API.
+//
+// Returns [ErrUserNotFound] if there is no user with that login name.
+func (s *Session) GetUserID(login string) (string, error) {
+	return s.GetUserIDContext(context.Background(), login)
+}
+
+// GetUserIDContext is like [Session.GetUserID] but uses ctx for the API requests.
+func (s *Session) GetUserIDContext(ctx context.Context, login string) (string, error) {
+	login = strings.ToLower(login)
+	if id, ok := s.userIDs.get(login); ok {
+		return id, nil
+	}
+
+	users, err := s.GetUsersByNameContext(ctx, login)
 	if err != nil {
-		return []*User{}, fmt.Errorf("get users by name: %v", err)
+		return "", err
+	}
+	if len(users) == 0 {
+		return "", ErrUserNotFound
 	}
 
-	return streamData.Data, nil
+	s.userIDs.set(login, users[0].ID)
+	return users[0].ID, nil
 }
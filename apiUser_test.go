@@ -0,0 +1,40 @@
+package twitchgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserUnmarshal(t *testing.T) {
+	// sample response of https://dev.twitch.tv/docs/api/reference/#get-users
+	const response = `{
+		"data": [{
+			"id": "141981764",
+			"login": "twitchdev",
+			"display_name": "TwitchDev",
+			"type": "staff",
+			"broadcaster_type": "partner",
+			"description": "Supporting third-party developers building Twitch integrations from chatbots to game integrations.",
+			"profile_image_url": "https://static-cdn.jtvnw.net/jtv_user_pictures/8a6381c7-d0c0-4576-b179-38bd5ce1d6af-profile_image-300x300.png",
+			"offline_image_url": "https://static-cdn.jtvnw.net/jtv_user_pictures/3f13ab61-ec78-4fe6-8481-8682cb3b0ac2-channel_offline_image-1920x1080.png",
+			"view_count": 5980557,
+			"email": "not-real@email.com",
+			"created_at": "2016-12-14T20:32:28Z"
+		}]
+	}`
+
+	var userData rawUserData
+	if err := json.Unmarshal([]byte(response), &userData); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(userData.Data) != 1 {
+		t.Fatalf("got %d users, want 1", len(userData.Data))
+	}
+	user := userData.Data[0]
+	if user.Type != "staff" {
+		t.Errorf("Type = %q, want %q", user.Type, "staff")
+	}
+	if user.BroadcasterType != "partner" {
+		t.Errorf("BroadcasterType = %q, want %q", user.BroadcasterType, "partner")
+	}
+}
@@ -0,0 +1,143 @@
+package twitchgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrNoVIPSlots is returned by [Session.AddVIP] when the broadcaster has no available VIP
+	// slots left.
+	ErrNoVIPSlots = errors.New("broadcaster has no available VIP slots")
+
+	// ErrVIPRequirementNotMet is returned by [Session.AddVIP] when the broadcaster has not yet
+	// completed the "Build a Community" requirement, which is needed to assign VIPs.
+	ErrVIPRequirementNotMet = errors.New("broadcaster must complete the Build a Community requirement to assign VIPs")
+
+	// ErrUserIsModerator is returned by [Session.AddVIP] when the user is a moderator. To make them
+	// a VIP, they have to be removed as a moderator first.
+	ErrUserIsModerator = errors.New("user is a moderator")
+
+	// ErrUserNotVIP is returned by [Session.RemoveVIP] when the user is not a VIP.
+	ErrUserNotVIP = errors.New("user is not a VIP")
+
+	// ErrVIPRateLimited is returned by [Session.AddVIP] and [Session.RemoveVIP] when the
+	// broadcaster exceeded the number of VIPs that may be added or removed within a 10-second
+	// window.
+	ErrVIPRateLimited = errors.New("too many VIP changes within 10 seconds")
+)
+
+type rawVIPData struct {
+	// The list of VIPs.
+	Data       []userReference `json:"data"`
+	Pagination pagination      `json:"pagination"`
+}
+
+// AddVIP adds the user to the broadcaster's list of VIPs. The current session has to have the
+// "channel:manage:vips" permission.
+//
+// Returns [ErrNoVIPSlots] if the broadcaster has no available VIP slots, [ErrUserIsModerator] if
+// the user is a moderator, [ErrVIPRequirementNotMet] if the broadcaster is not yet allowed to
+// assign VIPs and [ErrVIPRateLimited] if too many VIPs were added in a short period of time.
+func (s *Session) AddVIP(broadcasterID, userID string) error {
+	return s.AddVIPContext(context.Background(), broadcasterID, userID)
+}
+
+// AddVIPContext is like [Session.AddVIP] but uses ctx for the API requests.
+func (s *Session) AddVIPContext(ctx context.Context, broadcasterID, userID string) error {
+	if err := s.requireScope("channel:manage:vips"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"user_id":        {userID},
+	}
+
+	err := s.requestHelper(ctx, http.MethodPost, "/channels/vips", queryParams, nil, nil)
+	switch statusCode(err) {
+	case 0:
+		return nil
+	case http.StatusConflict:
+		return ErrNoVIPSlots
+	case http.StatusUnprocessableEntity:
+		return ErrUserIsModerator
+	case http.StatusTooEarly:
+		return ErrVIPRequirementNotMet
+	case http.StatusTooManyRequests:
+		return ErrVIPRateLimited
+	default:
+		return fmt.Errorf("add vip: %w", err)
+	}
+}
+
+// RemoveVIP removes the user from the broadcaster's list of VIPs. The current session has to have
+// the "channel:manage:vips" permission.
+//
+// Returns [ErrUserNotVIP] if the user is not a VIP and [ErrVIPRateLimited] if too many VIPs were
+// removed in a short period of time.
+func (s *Session) RemoveVIP(broadcasterID, userID string) error {
+	return s.RemoveVIPContext(context.Background(), broadcasterID, userID)
+}
+
+// RemoveVIPContext is like [Session.RemoveVIP] but uses ctx for the API requests.
+func (s *Session) RemoveVIPContext(ctx context.Context, broadcasterID, userID string) error {
+	if err := s.requireScope("channel:manage:vips"); err != nil {
+		return err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"user_id":        {userID},
+	}
+
+	err := s.requestHelper(ctx, http.MethodDelete, "/channels/vips", queryParams, nil, nil)
+	switch statusCode(err) {
+	case 0:
+		return nil
+	case http.StatusUnprocessableEntity:
+		return ErrUserNotVIP
+	case http.StatusTooManyRequests:
+		return ErrVIPRateLimited
+	default:
+		return fmt.Errorf("remove vip: %w", err)
+	}
+}
+
+// GetVIPs gets all VIPs of the broadcaster's channel. The current session has to have the
+// "channel:read:vips" or "channel:manage:vips" permission.
+//
+// The returned users only have their ID, Login and DisplayName set.
+func (s *Session) GetVIPs(broadcasterID string) (vips []*User, err error) {
+	return s.GetVIPsContext(context.Background(), broadcasterID)
+}
+
+// GetVIPsContext is like [Session.GetVIPs] but uses ctx for the API requests.
+func (s *Session) GetVIPsContext(ctx context.Context, broadcasterID string) (vips []*User, err error) {
+	if err := s.requireScope("channel:read:vips", "channel:manage:vips"); err != nil {
+		return nil, err
+	}
+
+	queryParams := map[string][]string{
+		"broadcaster_id": {broadcasterID},
+		"first":          {"100"},
+	}
+
+	for {
+		var vipData rawVIPData
+		err = s.requestHelper(ctx, http.MethodGet, "/channels/vips", queryParams, nil, &vipData)
+		if err != nil {
+			return nil, fmt.Errorf("get vips: %w", err)
+		}
+		for _, u := range vipData.Data {
+			vips = append(vips, u.user())
+		}
+		if vipData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{vipData.Pagination.Cursor}
+	}
+	return vips, nil
+}
@@ -0,0 +1,146 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type rawVideoData struct {
+	// The list of videos.
+	Data       []*Video   `json:"data"`
+	Pagination pagination `json:"pagination"`
+}
+
+// VideoParams are the filters used by [Session.GetVideos]. Exactly one of IDs, UserID or GameID
+// has to be set. The other filters can only be used together with UserID or GameID.
+type VideoParams struct {
+	// The IDs of the videos to get. Up to 100 IDs.
+	IDs []string
+	// The ID of the user whose videos to get.
+	UserID string
+	// The ID of the category or game to get videos for.
+	GameID string
+
+	// The type of videos to get. Possible values are:
+	//	"all" // default
+	//	"archive"
+	//	"highlight"
+	//	"upload"
+	Type string
+	// The ISO 639-1 two-letter language code or "other" to filter by. Can only be used with GameID.
+	Language string
+	// The time period to filter by. Possible values are:
+	//	"all" // default
+	//	"day"
+	//	"month"
+	//	"week"
+	Period string
+	// The order to sort the videos in. Possible values are:
+	//	"time" // default
+	//	"trending"
+	//	"views"
+	Sort string
+
+	// The maximum number of videos to get. If 0, GetVideos pages through all videos, so be careful
+	// with wide filters.
+	Limit int
+}
+
+// Video represents a video on demand (VOD) of a twitch user.
+type Video struct {
+	// An ID that identifies the video.
+	ID string `json:"id"`
+	// The ID of the stream that the video originated from if the video’s type is "archive";
+	// otherwise, empty.
+	StreamID string `json:"stream_id"`
+	// The ID of the broadcaster that owns the video.
+	UserID string `json:"user_id"`
+	// The broadcaster’s login name.
+	UserLogin string `json:"user_login"`
+	// The broadcaster’s display name.
+	UserName string `json:"user_name"`
+
+	// The video’s title.
+	Title string `json:"title"`
+	// The video’s description.
+	Description string `json:"description"`
+	// The date and time, in UTC, of when the video was created.
+	CreatedAt time.Time `json:"created_at"`
+	// The date and time, in UTC, of when the video was published.
+	PublishedAt time.Time `json:"published_at"`
+	// The video’s URL.
+	URL string `json:"url"`
+	// A URL to a thumbnail image of the video. Replace the width and height placeholders in the URL
+	// (%{width}x%{height}) with the size of the image you want, in pixels.
+	ThumbnailURL string `json:"thumbnail_url"`
+	// The video’s viewable state. Always set to "public".
+	Viewable string `json:"viewable"`
+	// The number of times that users have watched the video.
+	ViewCount int `json:"view_count"`
+	// The ISO 639-1 two-letter language code that the video was broadcast in.
+	Language string `json:"language"`
+	// The video’s type. Possible values are:
+	//	"archive"   // An on-demand video (VOD) of one of the broadcaster’s past streams.
+	//	"highlight" // A highlight reel of one of the broadcaster’s past streams.
+	//	"upload"    // A video that the broadcaster uploaded to their video library.
+	Type string `json:"type"`
+	// The video’s length e.g. "3m21s". It can be parsed with
+	// [time.ParseDuration].
+	Duration string `json:"duration"`
+}
+
+// GetVideos gets the videos matching the given filters.
+func (s *Session) GetVideos(params VideoParams) ([]*Video, error) {
+	return s.GetVideosContext(context.Background(), params)
+}
+
+// GetVideosContext is like [Session.GetVideos] but uses ctx for the API requests.
+func (s *Session) GetVideosContext(ctx context.Context, params VideoParams) (videos []*Video, err error) {
+	queryParams := map[string][]string{}
+	if len(params.IDs) > 0 {
+		queryParams["id"] = params.IDs
+	} else {
+		queryParams["first"] = []string{"100"}
+		if params.Limit > 0 && params.Limit < 100 {
+			queryParams["first"] = []string{strconv.Itoa(params.Limit)}
+		}
+	}
+	if params.UserID != "" {
+		queryParams["user_id"] = []string{params.UserID}
+	}
+	if params.GameID != "" {
+		queryParams["game_id"] = []string{params.GameID}
+	}
+	if params.Type != "" {
+		queryParams["type"] = []string{params.Type}
+	}
+	if params.Language != "" {
+		queryParams["language"] = []string{params.Language}
+	}
+	if params.Period != "" {
+		queryParams["period"] = []string{params.Period}
+	}
+	if params.Sort != "" {
+		queryParams["sort"] = []string{params.Sort}
+	}
+
+	for {
+		var videoData rawVideoData
+		err = s.requestHelper(ctx, http.MethodGet, "/videos", queryParams, nil, &videoData)
+		if err != nil {
+			return nil, fmt.Errorf("get videos: %w", err)
+		}
+		videos = append(videos, videoData.Data...)
+		if params.Limit > 0 && len(videos) >= params.Limit {
+			return videos[:params.Limit], nil
+		}
+		if videoData.Pagination.Cursor == "" {
+			break
+		}
+		queryParams["after"] = []string{videoData.Pagination.Cursor}
+	}
+	return videos, nil
+}
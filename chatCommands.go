@@ -0,0 +1,127 @@
+package twitchgo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The helpers in this file moderate a chat by the login names of the channel and the user. They
+// use the Twitch API, if the Session has API credentials, see [Session.SetAPI]. Otherwise they
+// fall back to sending the chat command, e.g. "/ban <user>", as a message to the channel. Twitch
+// no longer supports most chat commands sent over IRC, so the fallback may silently do nothing.
+
+// hasAPI reports whether s has credentials for the Twitch API.
+func (s *Session) hasAPI() bool {
+	return s.oauth != nil && s.clientID != "" && s.clientSecret != ""
+}
+
+// sendChatCommand sends the chat command text, e.g. "/ban foo", to the channel.
+func (s *Session) sendChatCommand(channel, text string) error {
+	return s.sendCommand(fmt.Sprintf("%s #%s :%s", IRCMsgCmdPrivmsg, channel, strings.TrimSpace(text)))
+}
+
+// channelAndUserIDs looks up the IDs of the channel and the user by their login names.
+func (s *Session) channelAndUserIDs(ctx context.Context, channel, user string) (string, string, error) {
+	broadcasterID, err := s.GetUserIDContext(ctx, channel)
+	if err != nil {
+		return "", "", fmt.Errorf("get channel id: %w", err)
+	}
+	userID, err := s.GetUserIDContext(ctx, user)
+	if err != nil {
+		return "", "", fmt.Errorf("get user id: %w", err)
+	}
+	return broadcasterID, userID, nil
+}
+
+// Ban bans the user from the chat of the channel permanently. reason is optional. Both, the channel
+// and the user, are login names.
+//
+// With API credentials, the user is banned by [Session.BanUser]. Otherwise the chat command
+// "/ban <user> <reason>" is sent to the channel.
+func (s *Session) Ban(channel, user, reason string) error {
+	return s.BanContext(context.Background(), channel, user, reason)
+}
+
+// BanContext is like [Session.Ban] but uses ctx for the API requests.
+func (s *Session) BanContext(ctx context.Context, channel, user, reason string) error {
+	return s.TimeoutContext(ctx, channel, user, 0, reason)
+}
+
+// Timeout puts the user in a timeout in the chat of the channel for duration. A duration of 0 bans
+// the user permanently, otherwise it has to be between 1 second and 2 weeks or [ErrInvalidTimeout]
+// is returned. reason is optional. Both, the channel and the user, are login names.
+//
+// With API credentials, the user is put in a timeout by [Session.BanUser]. Otherwise the chat
+// command "/timeout <user> <seconds> <reason>" is sent to the channel.
+func (s *Session) Timeout(channel, user string, duration time.Duration, reason string) error {
+	return s.TimeoutContext(context.Background(), channel, user, duration, reason)
+}
+
+// TimeoutContext is like [Session.Timeout] but uses ctx for the API requests.
+func (s *Session) TimeoutContext(ctx context.Context, channel, user string, duration time.Duration, reason string) error {
+	channel, user = NormalizeChannel(channel), strings.TrimPrefix(user, "@")
+	seconds, err := timeoutSeconds(duration)
+	if err != nil {
+		return err
+	}
+	if !s.hasAPI() {
+		if seconds == 0 {
+			return s.sendChatCommand(channel, fmt.Sprintf("/ban %s %s", user, reason))
+		}
+		return s.sendChatCommand(channel, fmt.Sprintf("/timeout %s %d %s", user, seconds, reason))
+	}
+
+	broadcasterID, userID, err := s.channelAndUserIDs(ctx, channel, user)
+	if err != nil {
+		return err
+	}
+	return s.BanUserContext(ctx, broadcasterID, userID, duration, reason)
+}
+
+// Unban removes the ban or timeout of the user in the chat of the channel. Both, the channel and
+// the user, are login names.
+//
+// With API credentials, the user is unbanned by [Session.UnbanUser]. Otherwise the chat command
+// "/unban <user>" is sent to the channel.
+func (s *Session) Unban(channel, user string) error {
+	return s.UnbanContext(context.Background(), channel, user)
+}
+
+// UnbanContext is like [Session.Unban] but uses ctx for the API requests.
+func (s *Session) UnbanContext(ctx context.Context, channel, user string) error {
+	channel, user = NormalizeChannel(channel), strings.TrimPrefix(user, "@")
+	if !s.hasAPI() {
+		return s.sendChatCommand(channel, "/unban "+user)
+	}
+
+	broadcasterID, userID, err := s.channelAndUserIDs(ctx, channel, user)
+	if err != nil {
+		return err
+	}
+	return s.UnbanUserContext(ctx, broadcasterID, userID)
+}
+
+// Announce sends an announcement to the chat of the channel, which is highlighted with the
+// channel’s accent color. The channel is a login name.
+//
+// With API credentials, the announcement is sent by [Session.SendAnnouncement]. Otherwise the chat
+// command "/announce <msg>" is sent to the channel.
+func (s *Session) Announce(channel, msg string) error {
+	return s.AnnounceContext(context.Background(), channel, msg)
+}
+
+// AnnounceContext is like [Session.Announce] but uses ctx for the API requests.
+func (s *Session) AnnounceContext(ctx context.Context, channel, msg string) error {
+	channel = NormalizeChannel(channel)
+	if !s.hasAPI() {
+		return s.sendChatCommand(channel, "/announce "+msg)
+	}
+
+	broadcasterID, err := s.GetUserIDContext(ctx, channel)
+	if err != nil {
+		return fmt.Errorf("get channel id: %w", err)
+	}
+	return s.SendAnnouncementContext(ctx, broadcasterID, msg, "")
+}
@@ -0,0 +1,44 @@
+package twitchgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeoutDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+		wantErr  error
+	}{
+		{duration: 0, want: "PRIVMSG #channel :/ban user"},
+		{duration: time.Second, want: "PRIVMSG #channel :/timeout user 1"},
+		{duration: 10 * time.Minute, want: "PRIVMSG #channel :/timeout user 600"},
+		{duration: 14 * 24 * time.Hour, want: "PRIVMSG #channel :/timeout user 1209600"},
+		{duration: 500 * time.Millisecond, wantErr: ErrInvalidTimeout},
+		{duration: -time.Minute, wantErr: ErrInvalidTimeout},
+		{duration: 14*24*time.Hour + time.Second, wantErr: ErrInvalidTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.duration.String(), func(t *testing.T) {
+			s, mock := NewMock()
+			s.SetRawLogger(nil)
+
+			err := s.Timeout("#channel", "user", tt.duration, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Timeout() error = %v, want %v", err, tt.wantErr)
+			}
+			sent := mock.Sent()
+			if tt.wantErr != nil {
+				if len(sent) != 0 {
+					t.Errorf("sent %q, want nothing", sent)
+				}
+				return
+			}
+			if len(sent) != 1 || sent[0] != tt.want {
+				t.Errorf("sent %q, want %q", sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package twitchgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandRouter dispatches a command to its subcommands by the first argument, e.g. the message
+// "!settings set color red" calls the subcommand "set" of the command "settings" with the
+// arguments "color" and "red". Routers can be nested to build a tree of commands.
+//
+// When no or an unknown subcommand is given, the router replies with a short usage message. The
+// subcommand "help" replies with the usage of every subcommand, unless a subcommand with that name
+// is registered.
+//
+// Use [Session.OnCommandRouter] to register a router.
+type CommandRouter struct {
+	name        string
+	subcommands []*subcommand
+}
+
+// subcommand is a single entry of a [CommandRouter]. Either callback or router is set.
+type subcommand struct {
+	name     string
+	usage    string
+	callback IRCChannelCommandMessageCallback
+	router   *CommandRouter
+}
+
+// NewCommandRouter creates a new router for the command with the given name.
+func NewCommandRouter(name string) *CommandRouter {
+	return &CommandRouter{name: strings.ToLower(name)}
+}
+
+// Handle registers callback for the subcommand with the given name. The subcommand is matched case
+// insensitive. usage describes the arguments of the subcommand, e.g. "<key> <value>", and is shown
+// in the help message. The callback receives the arguments after the subcommand.
+func (r *CommandRouter) Handle(name, usage string, callback IRCChannelCommandMessageCallback) *CommandRouter {
+	r.subcommands = append(r.subcommands, &subcommand{
+		name:     strings.ToLower(name),
+		usage:    usage,
+		callback: callback,
+	})
+	return r
+}
+
+// Mount registers router as a subcommand, named like router.
+func (r *CommandRouter) Mount(router *CommandRouter) *CommandRouter {
+	r.subcommands = append(r.subcommands, &subcommand{
+		name:   router.name,
+		router: router,
+	})
+	return r
+}
+
+// OnCommandRouter tells the bot to dispatch the command of router to its subcommands, see
+// [CommandRouter]. The command is matched like with [Session.OnChannelCommandMessage] ignoring the
+// case.
+func (s *Session) OnCommandRouter(router *CommandRouter) {
+	info := CommandInfo{Name: router.name, Usage: "<" + router.names() + ">"}
+	s.OnCommand(info, true, func(s *Session, channel string, source *IRCUser, args []string) {
+		router.dispatch(s, channel, source, args, s.commandPrefixes(channel)[0]+router.name)
+	})
+}
+
+// dispatch calls the subcommand named by the first of args. path is the command line leading to
+// this router, e.g. "!settings", used for the usage messages.
+func (r *CommandRouter) dispatch(s *Session, channel string, source *IRCUser, args []string, path string) {
+	if len(args) == 0 {
+		s.SendMessagef(channel, "Usage: %s <%s>", path, r.names())
+		return
+	}
+
+	name := strings.ToLower(args[0])
+	for _, sub := range r.subcommands {
+		if sub.name != name {
+			continue
+		}
+		if sub.router != nil {
+			sub.router.dispatch(s, channel, source, args[1:], path+" "+sub.name)
+		} else {
+			sub.callback(s, channel, source, args[1:])
+		}
+		return
+	}
+
+	if name == "help" {
+		s.SendMessage(channel, r.help(path))
+	} else {
+		s.SendMessagef(channel, "Unknown subcommand '%s'. Usage: %s <%s>", args[0], path, r.names())
+	}
+}
+
+// names returns the names of all subcommands separated by "|".
+func (r *CommandRouter) names() string {
+	names := make([]string, len(r.subcommands))
+	for i, sub := range r.subcommands {
+		names[i] = sub.name
+	}
+	return strings.Join(names, "|")
+}
+
+// help returns the usage of all subcommands.
+func (r *CommandRouter) help(path string) string {
+	usages := make([]string, len(r.subcommands))
+	for i, sub := range r.subcommands {
+		usage := sub.usage
+		if sub.router != nil {
+			usage = "<" + sub.router.names() + ">"
+		}
+		usages[i] = strings.TrimSpace(fmt.Sprintf("%s %s %s", path, sub.name, usage))
+	}
+	return "Usage: " + strings.Join(usages, " | ")
+}
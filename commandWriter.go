@@ -0,0 +1,78 @@
+package twitchgo
+
+import (
+	"net"
+	"sync"
+)
+
+// outboundCommand is a command queued to be written by the [commandWriter].
+type outboundCommand struct {
+	cmd    string
+	result chan<- error
+}
+
+// commandWriter serializes all commands sent to the Twitch IRC server. A single goroutine writes
+// the queued commands to the connection one after another, so commands sent from multiple
+// goroutines never interleave.
+type commandWriter struct {
+	mu    sync.Mutex
+	queue chan outboundCommand
+	// stop is closed to stop the writer goroutine
+	stop chan struct{}
+}
+
+// start starts the writer goroutine for conn. A previously started goroutine has to be stopped
+// first.
+func (w *commandWriter) start(s *Session, conn net.Conn) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	queue, stop := make(chan outboundCommand), make(chan struct{})
+	w.queue, w.stop = queue, stop
+	go func() {
+		for {
+			select {
+			case c := <-queue:
+				_, err := conn.Write([]byte(c.cmd))
+				if err != nil {
+					s.stats.sendFailures.Add(1)
+				} else {
+					s.stats.sent.Add(1)
+					s.logRaw(DirectionOutbound, c.cmd)
+				}
+				c.result <- err
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// halt stops the writer goroutine. Commands written afterwards fail with [net.ErrClosed].
+func (w *commandWriter) halt() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stop != nil {
+		close(w.stop)
+	}
+	w.queue, w.stop = nil, nil
+}
+
+// write queues cmd and waits until it was written to the connection.
+func (w *commandWriter) write(cmd string) error {
+	w.mu.Lock()
+	queue, stop := w.queue, w.stop
+	w.mu.Unlock()
+	if queue == nil {
+		return net.ErrClosed
+	}
+
+	result := make(chan error, 1)
+	select {
+	case queue <- outboundCommand{cmd, result}:
+		return <-result
+	case <-stop:
+		return net.ErrClosed
+	}
+}
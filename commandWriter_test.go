@@ -0,0 +1,42 @@
+package twitchgo
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCommandWriterConcurrent(t *testing.T) {
+	s, mock := NewMock()
+	s.SetRawLogger(nil)
+
+	const goroutines, commands = 20, 50
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < commands; i++ {
+				s.SendCommandf("PRIVMSG #channel :goroutine %d command %d", g, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	sent := mock.Sent()
+	if len(sent) != goroutines*commands {
+		t.Fatalf("got %d commands, want %d", len(sent), goroutines*commands)
+	}
+	seen := make(map[string]bool, len(sent))
+	for _, cmd := range sent {
+		seen[cmd] = true
+	}
+	for g := 0; g < goroutines; g++ {
+		for i := 0; i < commands; i++ {
+			cmd := fmt.Sprintf("PRIVMSG #channel :goroutine %d command %d", g, i)
+			if !seen[cmd] {
+				t.Errorf("command %q was not sent intact", cmd)
+			}
+		}
+	}
+}
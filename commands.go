@@ -0,0 +1,112 @@
+package twitchgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandInfo describes a command registered with [Session.OnCommand].
+type CommandInfo struct {
+	// The name of the command without the prefix, e.g. "so".
+	Name string
+	// A short description of what the command does, e.g. "Shouts out another streamer".
+	Description string
+	// The arguments of the command, e.g. "<user>".
+	Usage string
+}
+
+// commandCallback is called with the arguments of a command and the ID of the command message.
+type commandCallback func(s *Session, channel string, source *IRCUser, args []string, msgID string)
+
+// commandHandler is stored in the events of s for every registered command.
+type commandHandler struct {
+	info       CommandInfo
+	ignoreCase bool
+	callback   commandCallback
+}
+
+// handle calls the callback of h, if msg is the command of h.
+func (h *commandHandler) handle(s *Session, channel string, source *IRCUser, msg, msgID string) {
+	msgCommand, args, isCommand := s.cutCommand(channel, msg)
+	if !isCommand {
+		return
+	}
+
+	if h.ignoreCase {
+		msgCommand = strings.ToLower(msgCommand)
+	}
+	if msgCommand != h.info.Name {
+		return
+	}
+
+	h.callback(s, channel, source, args, msgID)
+}
+
+// Commands returns the infos of all registered commands in the order they were registered.
+// Commands registered without a description, e.g. by [Session.OnChannelCommandMessage], only have
+// their name set.
+func (s *Session) Commands() []CommandInfo {
+	var commands []CommandInfo
+	for _, c := range s.events[IRCMsgCmdPrivmsg] {
+		if h, ok := c.(*commandHandler); ok {
+			commands = append(commands, h.info)
+		}
+	}
+	return commands
+}
+
+// OnHelpCommand registers a help command with the given name, e.g. "help". Without arguments it
+// lists all registered commands, see [Session.Commands]. Lists longer than the message length limit
+// are sent as multiple messages. With the name of a command as the argument, it shows the usage
+// and description of that command.
+func (s *Session) OnHelpCommand(cmd string) {
+	info := CommandInfo{
+		Name:        cmd,
+		Description: "Lists all commands or shows the usage of a command",
+		Usage:       "[command]",
+	}
+	s.OnCommand(info, true, func(s *Session, channel string, source *IRCUser, args []string) {
+		prefix := s.commandPrefixes(channel)[0]
+		if len(args) == 0 {
+			for _, msg := range s.commandList(prefix) {
+				s.SendMessage(channel, msg)
+			}
+			return
+		}
+
+		name := strings.TrimPrefix(args[0], prefix)
+		for _, c := range s.Commands() {
+			if !strings.EqualFold(c.Name, name) {
+				continue
+			}
+			help := strings.TrimSpace(fmt.Sprintf("%s%s %s", prefix, c.Name, c.Usage))
+			if c.Description != "" {
+				help += " - " + c.Description
+			}
+			s.SendMessage(channel, help)
+			return
+		}
+		s.SendMessagef(channel, "Unknown command '%s'", name)
+	})
+}
+
+// commandList returns the messages listing all registered commands. Every message is at most
+// 500 characters long.
+func (s *Session) commandList(prefix string) []string {
+	var msgs []string
+	msg := "Commands:"
+	seen := make(map[string]bool)
+	for _, c := range s.Commands() {
+		if seen[c.Name] {
+			continue
+		}
+		seen[c.Name] = true
+		name := " " + prefix + c.Name
+		if len(msg)+len(name) > maxMessageLength {
+			msgs = append(msgs, msg)
+			msg = "Commands:"
+		}
+		msg += name
+	}
+	return append(msgs, msg)
+}
@@ -0,0 +1,103 @@
+package twitchgo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCondition is returned by the condition builders like [ConditionChannelFollow], when a
+// required key of the condition is empty.
+var ErrInvalidCondition = errors.New("invalid subscription condition")
+
+// condition builds a condition from key-value pairs and returns [ErrInvalidCondition], if one of
+// the values is empty.
+func condition(pairs ...string) (map[string]string, error) {
+	c := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidCondition, pairs[i])
+		}
+		c[pairs[i]] = pairs[i+1]
+	}
+	return c, nil
+}
+
+// ConditionChannelUpdate returns the condition of an [EventChannelUpdate] subscription.
+func ConditionChannelUpdate(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionChannelFollow returns the condition of an [EventChannelFollow] subscription.
+// moderatorID is the ID of the broadcaster or one of their moderators, who authorized the
+// subscription.
+func ConditionChannelFollow(broadcasterID, moderatorID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID, "moderator_user_id", moderatorID)
+}
+
+// ConditionChannelSubscribe returns the condition of an [EventChannelSubscribe] subscription.
+func ConditionChannelSubscribe(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionChannelSubscriptionGift returns the condition of an [EventChannelSubscriptionGift]
+// subscription.
+func ConditionChannelSubscriptionGift(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionChannelCheer returns the condition of an [EventChannelCheer] subscription.
+func ConditionChannelCheer(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionChannelRaid returns the condition of an [EventChannelRaid] subscription. Exactly one of
+// fromBroadcasterID, to get notified when the broadcaster raids another channel, and
+// toBroadcasterID, to get notified when the broadcaster gets raided, has to be set.
+func ConditionChannelRaid(fromBroadcasterID, toBroadcasterID string) (map[string]string, error) {
+	switch {
+	case fromBroadcasterID != "" && toBroadcasterID != "":
+		return nil, fmt.Errorf("%w: only one of from_broadcaster_user_id and to_broadcaster_user_id is allowed", ErrInvalidCondition)
+	case fromBroadcasterID != "":
+		return condition("from_broadcaster_user_id", fromBroadcasterID)
+	case toBroadcasterID != "":
+		return condition("to_broadcaster_user_id", toBroadcasterID)
+	default:
+		return nil, fmt.Errorf("%w: from_broadcaster_user_id or to_broadcaster_user_id is required", ErrInvalidCondition)
+	}
+}
+
+// ConditionChannelPointsCustomRewardRedemptionAdd returns the condition of an
+// [EventChannelPointsCustomRewardRedemptionAdd] subscription. rewardID is optional and limits the
+// notifications to the redemptions of a single reward.
+func ConditionChannelPointsCustomRewardRedemptionAdd(broadcasterID, rewardID string) (map[string]string, error) {
+	c, err := condition("broadcaster_user_id", broadcasterID)
+	if err != nil {
+		return nil, err
+	}
+	if rewardID != "" {
+		c["reward_id"] = rewardID
+	}
+	return c, nil
+}
+
+// ConditionChannelBan returns the condition of an [EventChannelBan] subscription.
+func ConditionChannelBan(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionChannelModerate returns the condition of an [EventChannelModerate] subscription.
+// moderatorID is the ID of the broadcaster or one of their moderators, who authorized the
+// subscription.
+func ConditionChannelModerate(broadcasterID, moderatorID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID, "moderator_user_id", moderatorID)
+}
+
+// ConditionStreamOnline returns the condition of an [EventStreamOnline] subscription.
+func ConditionStreamOnline(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
+
+// ConditionStreamOffline returns the condition of an [EventStreamOffline] subscription.
+func ConditionStreamOffline(broadcasterID string) (map[string]string, error) {
+	return condition("broadcaster_user_id", broadcasterID)
+}
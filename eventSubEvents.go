@@ -0,0 +1,157 @@
+package twitchgo
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+var eventSubCallbackEventMap = make(map[SubscriptionType]func(s *Session, sub *Subscription, event json.RawMessage, c interface{}))
+
+// OnEventSubNotification tells the bot to call the given callback function on every EventSub
+// notification, regardless of its subscription type. The event is passed as raw JSON, because its
+// structure depends on the type of the subscription.
+func (s *Session) OnEventSubNotification(callback EventSubNotificationCallback) {
+	s.eventSubEvents["*"] = append(s.eventSubEvents["*"], &callback)
+}
+
+// OnStreamOnline tells the bot to call the given callback function when a broadcaster starts a
+// stream. The bot needs to be subscribed to [EventStreamOnline] for that broadcaster.
+func (s *Session) OnStreamOnline(callback EventSubStreamOnlineCallback) {
+	s.eventSubEvents[EventStreamOnline] = append(s.eventSubEvents[EventStreamOnline], &callback)
+}
+
+// OnStreamOffline tells the bot to call the given callback function when a broadcaster stops a
+// stream. The bot needs to be subscribed to [EventStreamOffline] for that broadcaster.
+func (s *Session) OnStreamOffline(callback EventSubStreamOfflineCallback) {
+	s.eventSubEvents[EventStreamOffline] = append(s.eventSubEvents[EventStreamOffline], &callback)
+}
+
+// OnChannelUpdate tells the bot to call the given callback function when a broadcaster updates
+// the category, title, content classification labels, or broadcast language of their channel. The
+// bot needs to be subscribed to [EventChannelUpdate] for that broadcaster.
+func (s *Session) OnChannelUpdate(callback EventSubChannelUpdateCallback) {
+	s.eventSubEvents[EventChannelUpdate] = append(s.eventSubEvents[EventChannelUpdate], &callback)
+}
+
+type EventSubNotificationCallback func(s *Session, subscription *Subscription, event json.RawMessage)
+type EventSubStreamOnlineCallback func(s *Session, event *StreamOnlineEvent)
+type EventSubStreamOfflineCallback func(s *Session, event *StreamOfflineEvent)
+type EventSubChannelUpdateCallback func(s *Session, event *ChannelUpdateEvent)
+
+// StreamOnlineEvent is the event sent with a [EventStreamOnline] notification.
+type StreamOnlineEvent struct {
+	// The ID of the stream.
+	ID string `json:"id"`
+	// The broadcaster’s user ID.
+	BroadcasterUserID string `json:"broadcaster_user_id"`
+	// The broadcaster’s user login.
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	// The broadcaster’s user display name.
+	BroadcasterUserName string `json:"broadcaster_user_name"`
+	// The stream type. Possible values are:
+	//	"live"
+	//	"playlist"
+	//	"watch_party"
+	//	"premiere"
+	//	"rerun"
+	Type string `json:"type"`
+	// The timestamp at which the stream went online at.
+	StartedAt time.Time `json:"started_at"`
+}
+
+// StreamOfflineEvent is the event sent with a [EventStreamOffline] notification.
+type StreamOfflineEvent struct {
+	// The broadcaster’s user ID.
+	BroadcasterUserID string `json:"broadcaster_user_id"`
+	// The broadcaster’s user login.
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	// The broadcaster’s user display name.
+	BroadcasterUserName string `json:"broadcaster_user_name"`
+}
+
+// ChannelUpdateEvent is the event sent with a [EventChannelUpdate] notification.
+type ChannelUpdateEvent struct {
+	// The broadcaster’s user ID.
+	BroadcasterUserID string `json:"broadcaster_user_id"`
+	// The broadcaster’s user login.
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	// The broadcaster’s user display name.
+	BroadcasterUserName string `json:"broadcaster_user_name"`
+	// The channel’s stream title.
+	Title string `json:"title"`
+	// The channel’s broadcast language.
+	Language string `json:"language"`
+	// The channel’s category ID.
+	CategoryID string `json:"category_id"`
+	// The category name.
+	CategoryName string `json:"category_name"`
+	// Array of content classification label IDs currently applied on the Channel.
+	ContentClassificationLabels []string `json:"content_classification_labels"`
+}
+
+// decodeEvent unmarshals the raw event into v and logs on failure. It reports whether the decoding
+// was successfull.
+func decodeEvent(sub *Subscription, event json.RawMessage, v any) bool {
+	if err := json.Unmarshal(event, v); err != nil {
+		log.Printf("Failed to decode %s event of subscription %s: %v", sub.Type, sub.ID, err)
+		return false
+	}
+	return true
+}
+
+func init() {
+	eventSubCallbackEventMap[EventStreamOnline] = func(s *Session, sub *Subscription, event json.RawMessage, c interface{}) {
+		if f, ok := c.(*EventSubStreamOnlineCallback); ok {
+			var e StreamOnlineEvent
+			if decodeEvent(sub, event, &e) {
+				(*f)(s, &e)
+			}
+		}
+	}
+	eventSubCallbackEventMap[EventStreamOffline] = func(s *Session, sub *Subscription, event json.RawMessage, c interface{}) {
+		if f, ok := c.(*EventSubStreamOfflineCallback); ok {
+			var e StreamOfflineEvent
+			if decodeEvent(sub, event, &e) {
+				(*f)(s, &e)
+			}
+		}
+	}
+	eventSubCallbackEventMap[EventChannelUpdate] = func(s *Session, sub *Subscription, event json.RawMessage, c interface{}) {
+		if f, ok := c.(*EventSubChannelUpdateCallback); ok {
+			var e ChannelUpdateEvent
+			if decodeEvent(sub, event, &e) {
+				(*f)(s, &e)
+			}
+		}
+	}
+
+	// on any
+	eventSubCallbackEventMap["*"] = func(s *Session, sub *Subscription, event json.RawMessage, c interface{}) {
+		if f, ok := c.(*EventSubNotificationCallback); ok {
+			(*f)(s, sub, event)
+		}
+	}
+}
+
+// handleEventSubNotification calls all the registered callbacks for the type of the given
+// subscription.
+func (s *Session) handleEventSubNotification(sub *Subscription, event json.RawMessage) {
+	if s == nil || sub == nil {
+		return
+	}
+
+	// the callbacks run on the goroutine of the WebSocket or webhook server, so recover from
+	// panics like for IRC messages
+	m := &IRCMessage{Raw: string(event), Command: IRCMessageCommand{Name: IRCMessageCommandName(sub.Type)}}
+	if handleCallback := eventSubCallbackEventMap[sub.Type]; handleCallback != nil {
+		for _, c := range s.eventSubEvents[sub.Type] {
+			s.callSafe(m, func() { handleCallback(s, sub, event, c) })
+		}
+	}
+
+	handleCallback := eventSubCallbackEventMap["*"]
+	for _, c := range s.eventSubEvents["*"] {
+		s.callSafe(m, func() { handleCallback(s, sub, event, c) })
+	}
+}
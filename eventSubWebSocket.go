@@ -0,0 +1,185 @@
+package twitchgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventSubWebSocketURL is the URL of the Twitch EventSub WebSocket server.
+const EventSubWebSocketURL = "wss://eventsub.wss.twitch.tv/ws"
+
+// ErrEventSubNotConnected is returned when subscribing to an event using the WebSocket transport
+// before [Session.ConnectEventSub] was called.
+var ErrEventSubNotConnected = errors.New("not connected to eventsub websocket")
+
+// eventSubMessage is a single message received from the EventSub WebSocket server.
+type eventSubMessage struct {
+	Metadata struct {
+		// An ID that uniquely identifies the message.
+		MessageID string `json:"message_id"`
+		// The type of message. Possible values are:
+		//	"session_welcome"
+		//	"session_keepalive"
+		//	"notification"
+		//	"session_reconnect"
+		//	"revocation"
+		MessageType string `json:"message_type"`
+		// The UTC date and time that the message was sent.
+		MessageTimestamp time.Time `json:"message_timestamp"`
+	} `json:"metadata"`
+	Payload struct {
+		// Only included in "session_welcome" and "session_reconnect" messages.
+		Session *eventSubSession `json:"session"`
+		// Only included in "notification" and "revocation" messages.
+		Subscription *Subscription `json:"subscription"`
+		// Only included in "notification" messages. The structure depends on the subscription type.
+		Event json.RawMessage `json:"event"`
+	} `json:"payload"`
+}
+
+// eventSubSession contains information about a connection to the EventSub WebSocket server.
+type eventSubSession struct {
+	// An ID that uniquely identifies this WebSocket connection. Use this ID to set the session_id
+	// field in all subscription requests.
+	ID string `json:"id"`
+	// The connection’s status.
+	Status string `json:"status"`
+	// The maximum number of seconds that you should expect silence before receiving a keepalive
+	// message.
+	KeepaliveTimeoutSeconds int `json:"keepalive_timeout_seconds"`
+	// The URL to reconnect to if you get a "session_reconnect" message.
+	ReconnectURL string `json:"reconnect_url"`
+	// The UTC date and time that the connection was created.
+	ConnectedAt time.Time `json:"connected_at"`
+}
+
+// ConnectEventSub connects to the Twitch EventSub WebSocket server. After a successfull
+// connection, events can be subscribed with the WebSocket transport by passing an empty callback
+// URL to [Session.SubscribeToEvent].
+//
+// Twitch closes the connection, if there was no subscription within 10 seconds after connecting.
+func (s *Session) ConnectEventSub() error {
+	s.eventSubMu.Lock()
+	defer s.eventSubMu.Unlock()
+
+	if s.eventSubConn != nil {
+		return ErrAlreadyConnected
+	}
+
+	conn, session, err := dialEventSub(EventSubWebSocketURL)
+	if err != nil {
+		return err
+	}
+
+	s.eventSubConn = conn
+	s.eventSubSessionID = session.ID
+	go s.listenEventSub(conn, session.KeepaliveTimeoutSeconds)
+	return nil
+}
+
+// closeEventSub closes the connection to the EventSub WebSocket server, if connected.
+func (s *Session) closeEventSub() {
+	s.eventSubMu.Lock()
+	defer s.eventSubMu.Unlock()
+
+	if s.eventSubConn == nil {
+		return
+	}
+	s.eventSubConn.Close()
+	s.eventSubConn = nil
+	s.eventSubSessionID = ""
+}
+
+// dialEventSub connects to the given EventSub WebSocket URL and waits up to 10 seconds for the
+// welcome message.
+func dialEventSub(url string) (*websocket.Conn, *eventSubSession, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dial eventsub: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	var msg eventSubMessage
+	if err = conn.ReadJSON(&msg); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("read eventsub welcome message: %v", err)
+	}
+	if msg.Metadata.MessageType != "session_welcome" || msg.Payload.Session == nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("expected eventsub welcome message, but got '%s'", msg.Metadata.MessageType)
+	}
+
+	return conn, msg.Payload.Session, nil
+}
+
+// listenEventSub reads messages from conn until the connection is closed or replaced by a
+// reconnect.
+func (s *Session) listenEventSub(conn *websocket.Conn, keepaliveTimeoutSeconds int) {
+	// give the server a few more seconds than the keepalive timeout to send a message
+	timeout := time.Duration(keepaliveTimeoutSeconds+5) * time.Second
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		var msg eventSubMessage
+		err := conn.ReadJSON(&msg)
+		if err != nil {
+			s.eventSubMu.Lock()
+			if s.eventSubConn == conn {
+				log.Printf("EventSub connection lost: %v", err)
+				conn.Close()
+				s.eventSubConn = nil
+				s.eventSubSessionID = ""
+			}
+			s.eventSubMu.Unlock()
+			return
+		}
+
+		switch msg.Metadata.MessageType {
+		case "session_keepalive":
+			// nothing to do, the read deadline is reset anyway
+		case "notification":
+			s.handleEventSubNotification(msg.Payload.Subscription, msg.Payload.Event)
+		case "session_reconnect":
+			if msg.Payload.Session == nil {
+				continue
+			}
+			if s.reconnectEventSub(conn, msg.Payload.Session.ReconnectURL) {
+				return
+			}
+		case "revocation":
+			if sub := msg.Payload.Subscription; sub != nil {
+				log.Printf("EventSub subscription %s (%s) was revoked: %s", sub.ID, sub.Type, sub.Status)
+			}
+		}
+	}
+}
+
+// reconnectEventSub connects to the reconnect URL and replaces oldConn with the new connection.
+// The subscriptions are kept by Twitch. It reports whether oldConn was replaced and closed.
+func (s *Session) reconnectEventSub(oldConn *websocket.Conn, url string) bool {
+	conn, session, err := dialEventSub(url)
+	if err != nil {
+		log.Printf("EventSub reconnect failed: %v", err)
+		return false
+	}
+
+	s.eventSubMu.Lock()
+	defer s.eventSubMu.Unlock()
+
+	if s.eventSubConn != oldConn {
+		// closed in the meantime
+		conn.Close()
+		return true
+	}
+	s.eventSubConn = conn
+	s.eventSubSessionID = session.ID
+	oldConn.Close()
+
+	go s.listenEventSub(conn, session.KeepaliveTimeoutSeconds)
+	return true
+}
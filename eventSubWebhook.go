@@ -0,0 +1,159 @@
+package twitchgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Header names Twitch sends with every EventSub webhook request.
+const (
+	EventSubHeaderMessageID        = "Twitch-Eventsub-Message-Id"
+	EventSubHeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	EventSubHeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	EventSubHeaderMessageType      = "Twitch-Eventsub-Message-Type"
+)
+
+// eventSubMaxMessageAge is the maximum age of a webhook message. Older messages are rejected to
+// prevent replay attacks.
+const eventSubMaxMessageAge = 10 * time.Minute
+
+// eventSubWebhookMessage is the body of a request Twitch sends to a webhook callback.
+type eventSubWebhookMessage struct {
+	// Only included in "webhook_callback_verification" messages.
+	Challenge string `json:"challenge"`
+	// The subscription the message belongs to.
+	Subscription *Subscription `json:"subscription"`
+	// Only included in "notification" messages. The structure depends on the subscription type.
+	Event json.RawMessage `json:"event"`
+}
+
+// eventSubMessageIDs keeps track of already received webhook message IDs.
+type eventSubMessageIDs struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// isDuplicate reports whether id was already seen. Otherwise it remembers id.
+func (ids *eventSubMessageIDs) isDuplicate(id string) bool {
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+
+	if ids.seen == nil {
+		ids.seen = make(map[string]time.Time)
+	}
+	now := time.Now()
+	for seenID, t := range ids.seen {
+		if now.Sub(t) > eventSubMaxMessageAge {
+			delete(ids.seen, seenID)
+		}
+	}
+
+	if _, ok := ids.seen[id]; ok {
+		return true
+	}
+	ids.seen[id] = now
+	return false
+}
+
+// EventSubHandler returns a [http.Handler] to serve as the callback of webhook subscriptions.
+//
+// The handler verifies the signature of every request with the secret set by
+// [Session.SetWebhookSecret], responds to the challenge of new subscriptions and rejects replayed
+// messages. Verified notifications are dispatched to the registered EventSub callbacks like
+// [Session.OnStreamOnline].
+//
+// Until a secret is set, all requests are rejected with status 403.
+func (s *Session) EventSubHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "could not read body", http.StatusBadRequest)
+			return
+		}
+
+		if s.webhookSecret == "" {
+			// without a secret, any request would pass the signature verification
+			http.Error(w, "no webhook secret set", http.StatusForbidden)
+			return
+		}
+		if ok, err := VerifyEventSubSignature(s.webhookSecret, r.Header, body); err != nil || !ok {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+
+		timestamp, err := time.Parse(time.RFC3339Nano, r.Header.Get(EventSubHeaderMessageTimestamp))
+		if err != nil || time.Since(timestamp) > eventSubMaxMessageAge {
+			http.Error(w, "message too old", http.StatusBadRequest)
+			return
+		}
+		if s.eventSubMessageIDs.isDuplicate(r.Header.Get(EventSubHeaderMessageID)) {
+			// already handled, just acknowledge it again
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var msg eventSubWebhookMessage
+		if err = json.Unmarshal(body, &msg); err != nil {
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Header.Get(EventSubHeaderMessageType) {
+		case "webhook_callback_verification":
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(msg.Challenge))
+		case "notification":
+			w.WriteHeader(http.StatusNoContent)
+			s.handleEventSubNotification(msg.Subscription, msg.Event)
+		case "revocation":
+			w.WriteHeader(http.StatusNoContent)
+			if sub := msg.Subscription; sub != nil {
+				log.Printf("EventSub subscription %s (%s) was revoked: %s", sub.ID, sub.Type, sub.Status)
+			}
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// VerifyEventSubSignature reports whether the signature of an EventSub webhook message is valid.
+// The signature is the HMAC-SHA256 of the message ID, the timestamp and the body, using the secret
+// the subscription was created with. It is sent in the "Twitch-Eventsub-Message-Signature" header
+// in the form "sha256=<hex>".
+//
+// An error is returned, if the signature header is missing or malformed.
+func VerifyEventSubSignature(secret string, headers http.Header, body []byte) (bool, error) {
+	rawSignature := headers.Get(EventSubHeaderMessageSignature)
+	if rawSignature == "" {
+		return false, fmt.Errorf("missing header %s", EventSubHeaderMessageSignature)
+	}
+	signature, ok := strings.CutPrefix(rawSignature, "sha256=")
+	if !ok {
+		return false, fmt.Errorf("unsupported signature '%s'", rawSignature)
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("decode signature: %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(headers.Get(EventSubHeaderMessageID)))
+	mac.Write([]byte(headers.Get(EventSubHeaderMessageTimestamp)))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected), nil
+}
@@ -0,0 +1,48 @@
+package twitchgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestVerifyEventSubSignature(t *testing.T) {
+	const (
+		secret    = "s3cRe7"
+		id        = "e76c6bd4-55c9-4987-8304-da1588d8988b"
+		timestamp = "2019-11-16T10:11:12.634234626Z"
+		body      = `{"event":{"user_id":"1337"}}`
+		signature = "sha256=e0441bf1722d88d22d00e58a56d28e4f7c42480995971596e665b018511a12bd"
+	)
+
+	tests := []struct {
+		name      string
+		signature string
+		body      string
+		want      bool
+		wantErr   bool
+	}{
+		{name: "valid", signature: signature, body: body, want: true},
+		{name: "tampered body", signature: signature, body: `{"event":{"user_id":"1338"}}`},
+		{name: "missing header", body: body, wantErr: true},
+		{name: "unsupported algorithm", signature: "sha1=e0441bf1722d88d22d00e58a56d28e4f", body: body, wantErr: true},
+		{name: "malformed hex", signature: "sha256=not-hex", body: body, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			headers.Set(EventSubHeaderMessageID, id)
+			headers.Set(EventSubHeaderMessageTimestamp, timestamp)
+			if tt.signature != "" {
+				headers.Set(EventSubHeaderMessageSignature, tt.signature)
+			}
+
+			got, err := VerifyEventSubSignature(secret, headers, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyEventSubSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyEventSubSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,18 +1,33 @@
 package twitchgo
 
 import (
+	"log"
+	"strconv"
 	"strings"
 )
 
 var ircCallbackEventMap = make(map[IRCMessageCommandName]func(s *Session, m *IRCMessage, c interface{}))
 
 // OnChannelJoin tells the bot to call the given callback function when a user joins a channel that
-// you (the bot) already joined.
+// you (the bot) already joined. This includes the bot itself joining a channel, see
+// [Session.OnSelfJoin] to only get notified about that.
 func (s *Session) OnChannelJoin(callback IRCChannelJoinCallback) {
 	s.events[IRCMsgCmdJoin] = append(s.events[IRCMsgCmdJoin], &callback)
 }
 
-func (s *Session) OnChannelNotice(callback func(s *Session)) {
+// OnSelfJoin tells the bot to call the given callback function when the bot itself finished joining
+// a channel. Use this for per-channel setup, which should happen exactly once after joining.
+func (s *Session) OnSelfJoin(callback IRCSelfJoinCallback) {
+	s.events[IRCMsgCmdJoin] = append(s.events[IRCMsgCmdJoin], &callback)
+}
+
+// OnChannelNotice tells the bot to call the given callback function when Twitch sends a notice to
+// a channel. Twitch uses notices to report whether a command succeeded or failed, e.g. when a
+// message could not be sent because the channel is in slow mode.
+//
+// noticeType identifies the kind of notice, e.g. [NoticeMsgSlowMode]. The channel is "*" for
+// notices not related to a specific channel.
+func (s *Session) OnChannelNotice(callback IRCChannelNoticeCallback) {
 	s.events[IRCMsgCmdNotice] = append(s.events[IRCMsgCmdNotice], &callback)
 }
 
@@ -22,12 +37,47 @@ func (s *Session) OnChannelLeave(callback IRCChannelLeaveCallback) {
 	s.events[IRCMsgCmdPart] = append(s.events[IRCMsgCmdPart], &callback)
 }
 
+// OnBannedFromChannel tells the bot to call the given callback function when the bot was removed
+// from a channel without calling [Session.LeaveChannel]. Twitch does this when the bot gets banned
+// from the channel. The bot no longer receives messages from that channel afterwards.
+func (s *Session) OnBannedFromChannel(callback IRCBannedFromChannelCallback) {
+	s.events[IRCMsgCmdPart] = append(s.events[IRCMsgCmdPart], &callback)
+}
+
 // OnChannelMessage tells the bot to call the given callback function when someone sends a message
-// in a channel that you (the bot) already joined.
+// in a channel that you (the bot) already joined. Like for all callbacks, the channel is passed
+// without the leading "#", see [NormalizeChannel].
+//
+// This includes action messages (sent by "/me <msg>") with their markers. Use [CutAction] to
+// detect them or [Session.OnChannelAction] to only receive action messages.
 func (s *Session) OnChannelMessage(callback IRCChannelMessageCallback) {
 	s.events[IRCMsgCmdPrivmsg] = append(s.events[IRCMsgCmdPrivmsg], &callback)
 }
 
+// OnChannelAction tells the bot to call the given callback function when someone sends an action
+// message (by "/me <msg>") in a channel that you (the bot) already joined. The callback receives
+// the message without the action markers.
+func (s *Session) OnChannelAction(callback IRCChannelActionCallback) {
+	s.events[IRCMsgCmdPrivmsg] = append(s.events[IRCMsgCmdPrivmsg], &callback)
+}
+
+// OnCheer tells the bot to call the given callback function when someone cheers Bits with a message
+// in a channel that you (the bot) already joined. bits is the amount of Bits cheered.
+func (s *Session) OnCheer(callback IRCChannelCheerCallback) {
+	s.events[IRCMsgCmdPrivmsg] = append(s.events[IRCMsgCmdPrivmsg], &callback)
+}
+
+// OnFirstMessage tells the bot to call the given callback function when someone sends their very
+// first message in a channel that you (the bot) already joined. This is useful to greet first-time
+// chatters.
+func (s *Session) OnFirstMessage(callback IRCChannelMessageCallback) {
+	s.OnChannelMessage(func(s *Session, channel string, source *IRCUser, msg, msgID string, tags IRCMessageTags) {
+		if tags.FirstMessage {
+			callback(s, channel, source, msg, msgID, tags)
+		}
+	})
+}
+
 // OnGlobalUserState is called right after the bot has connected successfully. So this callback
 // function is only useful when adding Before calling Connect().
 //
@@ -48,17 +98,65 @@ func (s *Session) OnRoomState(callback IRCRoomStateCallback) {
 // OnChannelCommandMessage tells the bot to call the given callback function when someone sends a
 // command in a channel that you (the bot) already joined.
 // A command is defined by a prefix (usually "!"), e.g. the message "!foo bar" translates to the
-// command "foo" with the argument "bar".
+// command "foo" with the argument "bar". The prefix is s.Prefix, unless other prefixes are set by
+// [Session.SetPrefixes] or [Session.SetChannelPrefix]. Commands can also be sent by mentioning the
+// bot, if enabled by [Session.SetMentionCommands].
+//
+// See [Session.OnCommand] to register a command with a description for the help command.
 func (s *Session) OnChannelCommandMessage(cmd string, ignoreCase bool, callback IRCChannelCommandMessageCallback) {
+	s.OnCommand(CommandInfo{Name: cmd}, ignoreCase, callback)
+}
+
+// OnCommand is like OnChannelCommandMessage, but additionally stores the description and usage
+// of the command. They are returned by [Session.Commands] and shown by the help command registered
+// with [Session.OnHelpCommand].
+func (s *Session) OnCommand(info CommandInfo, ignoreCase bool, callback IRCChannelCommandMessageCallback) {
+	s.onCommand(info, ignoreCase, func(s *Session, channel string, source *IRCUser, args []string, msgID string) {
+		callback(s, channel, source, args)
+	})
+}
+
+// OnChannelCommandReply is like OnChannelCommandMessage, but additionally passes a reply function
+// to the callback. Calling reply sends a message to the channel as a reply to the command message,
+// like [Session.SendReply].
+func (s *Session) OnChannelCommandReply(cmd string, ignoreCase bool, callback IRCChannelCommandReplyCallback) {
+	s.onCommand(CommandInfo{Name: cmd}, ignoreCase, func(s *Session, channel string, source *IRCUser, args []string, msgID string) {
+		callback(s, channel, source, args, func(msg string) {
+			s.SendReply(channel, msgID, msg)
+		})
+	})
+}
+
+// onCommand registers callback for the command described by info, see
+// [Session.OnChannelCommandMessage].
+func (s *Session) onCommand(info CommandInfo, ignoreCase bool, callback commandCallback) {
 	if ignoreCase {
-		cmd = strings.ToLower(cmd)
+		info.Name = strings.ToLower(info.Name)
 	}
-	s.OnChannelMessage(func(s *Session, channel string, source *IRCUser, msg, msgID string, tags IRCMessageTags) {
-		args := strings.Split(msg, " ")
-		msgCommand := args[0]
+	s.events[IRCMsgCmdPrivmsg] = append(s.events[IRCMsgCmdPrivmsg], &commandHandler{
+		info:       info,
+		ignoreCase: ignoreCase,
+		callback:   callback,
+	})
+}
+
+// OnWhisper tells the bot to call the given callback function when someone sends a whisper (a
+// private message) to the bot. The [CapCommands] capability is needed to receive whispers.
+func (s *Session) OnWhisper(callback IRCWhisperCallback) {
+	s.events[IRCMsgCmdWhisper] = append(s.events[IRCMsgCmdWhisper], &callback)
+}
 
-		msgCommand, hasPrefix := strings.CutPrefix(msgCommand, s.Prefix)
-		if !hasPrefix {
+// OnWhisperCommand is like OnChannelCommandMessage, but for commands sent to the bot in a whisper,
+// see [Session.OnWhisper]. The command has to start with one of the global prefixes, see
+// [Session.SetPrefixes]. This is useful for commands, which should not clutter the chat, like
+// admin commands.
+func (s *Session) OnWhisperCommand(cmd string, ignoreCase bool, callback IRCWhisperCommandCallback) {
+	if ignoreCase {
+		cmd = strings.ToLower(cmd)
+	}
+	s.OnWhisper(func(s *Session, from *IRCUser, msg string, tags IRCMessageTags) {
+		msgCommand, args, isCommand := s.cutCommand("", msg)
+		if !isCommand {
 			return
 		}
 
@@ -69,38 +167,168 @@ func (s *Session) OnChannelCommandMessage(cmd string, ignoreCase bool, callback
 			return
 		}
 
-		callback(s, channel, source, args[1:])
+		callback(s, from, args)
 	})
 }
 
+// OnHostTarget tells the bot to call the given callback function when a channel that you (the
+// bot) already joined starts or stops hosting another channel. When hosting stops, targetChannel is
+// empty. viewers is the number of viewers hostingChannel had when starting to host, or 0 if
+// unknown.
+//
+// Hosting is deprecated by Twitch, but the message may still be received.
+func (s *Session) OnHostTarget(callback IRCHostTargetCallback) {
+	s.events[IRCMsgCmdHosttarget] = append(s.events[IRCMsgCmdHosttarget], &callback)
+}
+
+// OnParseError tells the bot to call the given callback function when a message received from the
+// Twitch IRC server could not be parsed. raw is the line as received and err wraps
+// [ErrMalformedMessage]. This is usefull to find out about protocol issues during development.
+//
+// Messages with only malformed tags are still passed to the other callbacks with empty tags.
+func (s *Session) OnParseError(callback IRCParseErrorCallback) {
+	s.events[ircEventParseError] = append(s.events[ircEventParseError], &callback)
+}
+
+// OnPanic tells the bot to call the given callback function when a callback or middleware
+// panicked while handling a message. recovered is the value the callback panicked with and m is
+// the message that was handled. The bot recovers from the panic and keeps handling messages either
+// way.
+//
+// For a message, which could not be parsed, m only has Raw set. For an EventSub notification, m
+// only has Raw set to the event and Command.Name set to the type of the subscription.
+func (s *Session) OnPanic(callback IRCPanicCallback) {
+	s.events[ircEventPanic] = append(s.events[ircEventPanic], &callback)
+}
+
 // OnAny is called on any event. This is usefull for debug purposes.
 func (s *Session) OnAny(callback IRCAnyCallback) {
 	s.events["*"] = append(s.events["*"], &callback)
 }
 
 type IRCChannelJoinCallback func(s *Session, channel string, source *IRCUser)
+type IRCSelfJoinCallback func(s *Session, channel string)
 type IRCChannelLeaveCallback func(s *Session, channel string, source *IRCUser)
+type IRCBannedFromChannelCallback func(s *Session, channel string)
 type IRCChannelMessageCallback func(s *Session, channel string, source *IRCUser, msg, msgID string, tags IRCMessageTags)
+type IRCChannelActionCallback func(s *Session, channel string, source *IRCUser, msg, msgID string, tags IRCMessageTags)
+type IRCChannelCheerCallback func(s *Session, channel string, source *IRCUser, bits int, msg string, tags IRCMessageTags)
 type IRCChannelCommandMessageCallback func(s *Session, channel string, source *IRCUser, args []string)
+type IRCChannelCommandReplyCallback func(s *Session, channel string, source *IRCUser, args []string, reply func(msg string))
+type IRCChannelNoticeCallback func(s *Session, channel string, noticeType NoticeType, msg string)
+type IRCWhisperCallback func(s *Session, from *IRCUser, msg string, tags IRCMessageTags)
+type IRCWhisperCommandCallback func(s *Session, from *IRCUser, args []string)
+type IRCHostTargetCallback func(s *Session, hostingChannel, targetChannel string, viewers int)
 type IRCGlobalUserStateCallback func(s *Session, userTags IRCMessageTags)
 type IRCRoomStateCallback func(s *Session, roomTags IRCMessageTags)
 
 type IRCAnyCallback func(s *Session, message IRCMessage)
+type IRCParseErrorCallback func(s *Session, raw string, err error)
+type IRCPanicCallback func(s *Session, recovered any, m IRCMessage)
+
+// ircEventParseError is the key of the callbacks registered with [Session.OnParseError]. It can't
+// collide with a real command, because commands never contain a space.
+const ircEventParseError IRCMessageCommandName = "parse error"
+
+// ircEventPanic is the key of the callbacks registered with [Session.OnPanic].
+const ircEventPanic IRCMessageCommandName = "callback panic"
+
+// handleBannedFromChannel calls all the callbacks registered with [Session.OnBannedFromChannel]
+// for the PART message m.
+func (s *Session) handleBannedFromChannel(m *IRCMessage, channel string) {
+	for _, c := range s.events[IRCMsgCmdPart] {
+		if f, ok := c.(*IRCBannedFromChannelCallback); ok {
+			s.callSafe(m, func() { (*f)(s, channel) })
+		}
+	}
+}
+
+// handlePanic calls all the callbacks registered with [Session.OnPanic]. A panic in one of them is
+// only logged.
+func (s *Session) handlePanic(recovered any, m *IRCMessage) {
+	for _, c := range s.events[ircEventPanic] {
+		if f, ok := c.(*IRCPanicCallback); ok {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						log.Printf("Recovered from panic in panic callback: %v", r)
+					}
+				}()
+				(*f)(s, recovered, *m)
+			}()
+		}
+	}
+}
+
+// handleParseError calls all the callbacks registered with [Session.OnParseError].
+func (s *Session) handleParseError(raw string, err error) {
+	m := &IRCMessage{Raw: raw}
+	for _, c := range s.events[ircEventParseError] {
+		if f, ok := c.(*IRCParseErrorCallback); ok {
+			s.callSafe(m, func() { (*f)(s, raw, err) })
+		}
+	}
+}
 
 func init() {
 	ircCallbackEventMap[IRCMsgCmdJoin] = func(s *Session, m *IRCMessage, c interface{}) {
-		if f, ok := c.(*IRCChannelJoinCallback); ok {
-			(*f)(s, m.Command.Arguments[0], m.Source)
+		switch f := c.(type) {
+		case *IRCChannelJoinCallback:
+			(*f)(s, NormalizeChannel(m.Command.Arguments[0]), m.Source)
+		case *IRCSelfJoinCallback:
+			if m.Source != nil && m.Source.Nickname == s.login {
+				(*f)(s, NormalizeChannel(m.Command.Arguments[0]))
+			}
 		}
 	}
 	ircCallbackEventMap[IRCMsgCmdPart] = func(s *Session, m *IRCMessage, c interface{}) {
 		if f, ok := c.(*IRCChannelLeaveCallback); ok {
-			(*f)(s, m.Command.Arguments[0], m.Source)
+			(*f)(s, NormalizeChannel(m.Command.Arguments[0]), m.Source)
 		}
 	}
 	ircCallbackEventMap[IRCMsgCmdPrivmsg] = func(s *Session, m *IRCMessage, c interface{}) {
-		if f, ok := c.(*IRCChannelMessageCallback); ok {
-			(*f)(s, m.Command.Arguments[0], m.Source, m.Command.Data, m.Tags.ID, m.Tags)
+		if m.Source != nil {
+			m.Source.setChatTags(m.Tags)
+		}
+
+		switch f := c.(type) {
+		case *IRCChannelMessageCallback:
+			(*f)(s, NormalizeChannel(m.Command.Arguments[0]), m.Source, m.Command.Data, m.Tags.ID, m.Tags)
+		case *IRCChannelActionCallback:
+			if msg, isAction := CutAction(m.Command.Data); isAction {
+				(*f)(s, NormalizeChannel(m.Command.Arguments[0]), m.Source, msg, m.Tags.ID, m.Tags)
+			}
+		case *IRCChannelCheerCallback:
+			if m.Tags.Bits > 0 {
+				(*f)(s, NormalizeChannel(m.Command.Arguments[0]), m.Source, m.Tags.Bits, m.Command.Data, m.Tags)
+			}
+		case *commandHandler:
+			f.handle(s, NormalizeChannel(m.Command.Arguments[0]), m.Source, m.Command.Data, m.Tags.ID)
+		}
+	}
+	ircCallbackEventMap[IRCMsgCmdWhisper] = func(s *Session, m *IRCMessage, c interface{}) {
+		if m.Source != nil {
+			m.Source.setChatTags(m.Tags)
+		}
+
+		if f, ok := c.(*IRCWhisperCallback); ok {
+			(*f)(s, m.Source, m.Command.Data, m.Tags)
+		}
+	}
+	ircCallbackEventMap[IRCMsgCmdNotice] = func(s *Session, m *IRCMessage, c interface{}) {
+		if f, ok := c.(*IRCChannelNoticeCallback); ok {
+			(*f)(s, NormalizeChannel(m.Command.Arguments[0]), NoticeType(m.Tags.MsgType), m.Command.Data)
+		}
+	}
+	ircCallbackEventMap[IRCMsgCmdHosttarget] = func(s *Session, m *IRCMessage, c interface{}) {
+		if f, ok := c.(*IRCHostTargetCallback); ok {
+			// data is "<target> [<viewers>]", with target "-" when hosting stops
+			target, rawViewers, _ := strings.Cut(m.Command.Data, " ")
+			if target == "-" {
+				target = ""
+			}
+			viewers, _ := strconv.Atoi(rawViewers)
+			(*f)(s, NormalizeChannel(m.Command.Arguments[0]), target, viewers)
 		}
 	}
 	ircCallbackEventMap[IRCMsgCmdGlobaluserstate] = func(s *Session, m *IRCMessage, c interface{}) {
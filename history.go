@@ -0,0 +1,91 @@
+package twitchgo
+
+import (
+	"sync"
+)
+
+// messageHistory keeps the last messages of every channel.
+type messageHistory struct {
+	mu   sync.Mutex
+	size int
+	// rings are the messages of every channel. Once a ring is full, next points to the oldest
+	// message, which is overwritten next.
+	rings map[string]*messageRing
+}
+
+type messageRing struct {
+	messages []*IRCMessage
+	next     int
+}
+
+// record adds m to the history of its channel, if the history is enabled and m is a chat message.
+func (h *messageHistory) record(m *IRCMessage) {
+	if m.Command.Name != IRCMsgCmdPrivmsg || len(m.Command.Arguments) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.size <= 0 {
+		return
+	}
+	if h.rings == nil {
+		h.rings = make(map[string]*messageRing)
+	}
+	channel := NormalizeChannel(m.Command.Arguments[0])
+	ring, ok := h.rings[channel]
+	if !ok {
+		ring = &messageRing{}
+		h.rings[channel] = ring
+	}
+
+	msg := *m
+	if m.Source != nil {
+		source := *m.Source
+		source.setChatTags(m.Tags)
+		msg.Source = &source
+	}
+	if len(ring.messages) < h.size {
+		ring.messages = append(ring.messages, &msg)
+		return
+	}
+	ring.messages[ring.next] = &msg
+	ring.next = (ring.next + 1) % h.size
+}
+
+// recent returns the last n messages of channel, the oldest first.
+func (h *messageHistory) recent(channel string, n int) []*IRCMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ring, ok := h.rings[channel]
+	if !ok || n <= 0 {
+		return nil
+	}
+	ordered := append(append([]*IRCMessage{}, ring.messages[ring.next:]...), ring.messages[:ring.next]...)
+	if n < len(ordered) {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
+
+// SetMessageHistory sets the number of chat messages kept for every joined channel, see
+// [Session.RecentMessages]. Changing the size forgets all kept messages. The history is disabled
+// by default, setting size to 0 disables it again.
+func (s *Session) SetMessageHistory(size int) *Session {
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+	s.history.size = size
+	s.history.rings = nil
+	return s
+}
+
+// RecentMessages returns up to the last n chat messages received in the given channel, the oldest
+// first. The messages are only kept, if enabled by [Session.SetMessageHistory]. Messages dropped
+// by a middleware are not kept. This is useful for moderation tools, which need the context of a
+// message.
+//
+// The returned messages are shared with other callers and must not be modified.
+func (s *Session) RecentMessages(channel string, n int) []*IRCMessage {
+	return s.history.recent(NormalizeChannel(channel), n)
+}
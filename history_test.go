@@ -0,0 +1,32 @@
+package twitchgo
+
+import "testing"
+
+func TestMessageHistorySkipsDroppedMessages(t *testing.T) {
+	s, mock := NewMock()
+	s.SetMessageHistory(10)
+	s.Use(func(next Handler) Handler {
+		return func(s *Session, m *IRCMessage) {
+			if m.Source != nil && m.Source.Nickname == "spammer" {
+				return
+			}
+			next(s, m)
+		}
+	})
+
+	mock.Feed(
+		":viewer!viewer@viewer.tmi.twitch.tv PRIVMSG #channel :first",
+		":spammer!spammer@spammer.tmi.twitch.tv PRIVMSG #channel :buy followers",
+		":viewer!viewer@viewer.tmi.twitch.tv PRIVMSG #channel :second",
+	)
+
+	messages := s.RecentMessages("#channel", 10)
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+	for i, want := range []string{"first", "second"} {
+		if messages[i].Command.Data != want {
+			t.Errorf("message %d = %q, want %q", i, messages[i].Command.Data, want)
+		}
+	}
+}
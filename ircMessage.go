@@ -1,5 +1,13 @@
 package twitchgo
 
+import "strings"
+
+// The CTCP markers wrapping an action message, e.g. "\x01ACTION waves\x01".
+const (
+	actionPrefix = "\x01ACTION "
+	actionSuffix = "\x01"
+)
+
 // IRCMessage contains the basic data for a message from the IRC server.
 type IRCMessage struct {
 	Raw     string
@@ -7,3 +15,13 @@ type IRCMessage struct {
 	Source  *IRCUser
 	Command IRCMessageCommand
 }
+
+// CutAction returns msg without the markers of an action message (sent by "/me <msg>") and reports
+// whether msg was an action message. If it was not, msg is returned unchanged.
+func CutAction(msg string) (text string, isAction bool) {
+	text, isAction = strings.CutPrefix(msg, actionPrefix)
+	if !isAction {
+		return msg, false
+	}
+	return strings.TrimSuffix(text, actionSuffix), true
+}
@@ -33,6 +33,8 @@ const (
 	IRCMsgCmdPing IRCMessageCommandName = "PING"
 	// Your bot sends this message in reply to the Twitch IRC server’s PING message.
 	IRCMsgCmdPong IRCMessageCommandName = "PONG"
+	// Your bot sends this message to disconnect from the Twitch IRC server.
+	IRCMsgCmdQuit IRCMessageCommandName = "QUIT"
 	// Your bot sends this message to post a chat message in the channel’s chat room.
 	//
 	// Your bot receives this message from the Twitch IRC server when a user posts a chat message in
@@ -20,7 +20,7 @@ type IRCMessageTags struct {
 	// Optional. The ID of the user that was banned or put in a timeout. The user was banned if the
 	// message doesn’t include the ban-duration tag.
 	TargetUserID string `json:"target-user-id"`
-	// The UNIX timestamp.
+	// The UNIX timestamp, in UTC with millisecond precision.
 	Timestamp time.Time `json:"tmi-sent-ts"`
 
 	// The name of the user who sent the message.
@@ -316,7 +316,65 @@ func (t IRCMessageTags) IsBroadcaster() bool {
 	return t.HasBadge("broadcaster")
 }
 
+// CumulativeMonths returns msg-param-cumulative-months as an int. It returns 0 if the tag is
+// missing or invalid.
+func (t IRCMessageTags) CumulativeMonths() int {
+	return atoiOrZero(t.MsgParamCumulativeMonths)
+}
+
+// Months returns msg-param-months as an int. It returns 0 if the tag is missing or invalid.
+func (t IRCMessageTags) Months() int {
+	return atoiOrZero(t.MsgParamMonths)
+}
+
+// StreakMonths returns msg-param-streak-months as an int. It returns 0 if the tag is missing or
+// invalid.
+func (t IRCMessageTags) StreakMonths() int {
+	return atoiOrZero(t.MsgParamStreakMonths)
+}
+
+// ViewerCount returns msg-param-viewerCount as an int. It returns 0 if the tag is missing or
+// invalid.
+func (t IRCMessageTags) ViewerCount() int {
+	return atoiOrZero(t.MsgParamViewerCount)
+}
+
+// GiftMonths returns msg-param-gift-months as an int. It returns 0 if the tag is missing or
+// invalid.
+func (t IRCMessageTags) GiftMonths() int {
+	return atoiOrZero(t.MsgParamGiftMonths)
+}
+
+// Threshold returns msg-param-threshold as an int. It returns 0 if the tag is missing or invalid.
+func (t IRCMessageTags) Threshold() int {
+	return atoiOrZero(t.MsgParamThreshold)
+}
+
+// PromoGiftTotal returns msg-param-promo-gift-total as an int. It returns 0 if the tag is missing
+// or invalid.
+func (t IRCMessageTags) PromoGiftTotal() int {
+	return atoiOrZero(t.MsgParamPromoGiftTotal)
+}
+
+// atoiOrZero is like [strconv.Atoi], but returns 0 on error.
+func atoiOrZero(s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
 func ParseRawIRCTags(raw string) IRCMessageTags {
+	t, err := parseRawIRCTags(raw)
+	if err != nil {
+		log.Print(err)
+	}
+	return t
+}
+
+// parseRawIRCTags is like [ParseRawIRCTags], but returns an error instead of logging it.
+func parseRawIRCTags(raw string) (IRCMessageTags, error) {
 	var b []byte
 	b = append(b, '{')
 	for i, t := range strings.Split(raw, `;`) {
@@ -329,18 +387,73 @@ func ParseRawIRCTags(raw string) IRCMessageTags {
 	t := IRCMessageTags{}
 	err := json.Unmarshal(b, &t)
 	if err != nil {
-		log.Printf("Failed to parse Tags err: %+v\nraw: %s\nformated: %s", err, raw, string(b))
-		return IRCMessageTags{}
+		return IRCMessageTags{}, fmt.Errorf("failed to parse tags: %v\nraw: %s\nformated: %s", err, raw, string(b))
 	}
-	return t
+	return t, nil
+}
+
+// EscapeTagValue escapes value to be used as the value of an IRC tag, following the IRCv3 rules.
+// Semicolons, spaces, backslashes, carriage returns and line feeds are replaced with "\:", "\s",
+// "\\", "\r" and "\n".
+func EscapeTagValue(value string) string {
+	return tagValueEscaper.Replace(value)
+}
+
+var tagValueEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\:`,
+	" ", `\s`,
+	"\r", `\r`,
+	"\n", `\n`,
+)
+
+// UnescapeTagValue reverses [EscapeTagValue], following the IRCv3 rules. A backslash followed by
+// any other character is dropped and a trailing backslash is removed.
+func UnescapeTagValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(value) {
+			break
+		}
+		switch value[i] {
+		case ':':
+			b.WriteByte(';')
+		case 's':
+			b.WriteByte(' ')
+		case 'r':
+			b.WriteByte('\r')
+		case 'n':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// jsonString returns s as a quoted JSON string.
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
 }
 
 func formatRawIRCTag(raw string) []byte {
 	var b []byte
-	tagPair := strings.Split(raw, "=")
-	if len(tagPair) != 2 {
-		return []byte(fmt.Sprintf("\"%s\":\"\"", raw))
+	key, value, ok := strings.Cut(raw, "=")
+	if !ok {
+		return []byte(fmt.Sprintf("%s:\"\"", jsonString(raw)))
 	}
+	tagPair := []string{key, UnescapeTagValue(value)}
 
 	var i IRCMessageTags
 	t := reflect.TypeOf(i)
@@ -353,14 +466,22 @@ func formatRawIRCTag(raw string) []byte {
 		}
 		found = true
 
-		tagPair[1] = strings.ReplaceAll(tagPair[1], "\\s", " ")
-		tagPair[1] = strings.ReplaceAll(tagPair[1], "\\", "\\\\")
-
 		switch f.Type.Kind() {
 		case reflect.Slice:
-			tagPair[1] = fmt.Sprintf("[\"%s\"]", strings.ReplaceAll(tagPair[1], ",", "\",\""))
+			if tagPair[1] == "" {
+				// an empty tag is an empty list, like the zero value
+				tagPair[1] = "null"
+				break
+			}
+			items, _ := json.Marshal(strings.Split(tagPair[1], ","))
+			tagPair[1] = string(items)
 		case reflect.Int:
-			tagPair[1] = fmt.Sprintf("%s", tagPair[1])
+			if _, err := strconv.Atoi(tagPair[1]); err != nil {
+				if tagPair[1] != "" {
+					log.Printf("Could not parse int from '%s' (json:'%s'): %+v", tagPair[1], jsonTag, err)
+				}
+				tagPair[1] = "0"
+			}
 		case reflect.Bool:
 			if tagPair[1] == "1" || tagPair[1] == "true" {
 				tagPair[1] = "true"
@@ -368,7 +489,7 @@ func formatRawIRCTag(raw string) []byte {
 				tagPair[1] = "false"
 			}
 		case reflect.String:
-			tagPair[1] = fmt.Sprintf("\"%s\"", tagPair[1])
+			tagPair[1] = jsonString(tagPair[1])
 		case reflect.Struct:
 			if f.Type == reflect.TypeOf(time.Time{}) {
 				ts, err := strconv.Atoi(tagPair[1])
@@ -377,20 +498,21 @@ func formatRawIRCTag(raw string) []byte {
 					tagPair[1] = fmt.Sprintf("\"%s\"", tagPair[1])
 					break
 				}
-				tagPair[1] = "\"" + time.Unix(0, int64(ts)).Format(time.RFC3339) + "\""
+				// the timestamp is in milliseconds
+				tagPair[1] = "\"" + time.UnixMilli(int64(ts)).UTC().Format(time.RFC3339Nano) + "\""
 			}
 		default:
-			tagPair[1] = fmt.Sprintf("\"%s\"", tagPair[1])
+			tagPair[1] = jsonString(tagPair[1])
 			log.Printf("formated %+v '%d' (json:'%s') as string", f.Type, f.Type.Kind(), jsonTag)
 		}
 		break
 	}
 	if !found {
-		tagPair[1] = fmt.Sprintf("\"%s\"", tagPair[1])
+		tagPair[1] = jsonString(tagPair[1])
 		log.Printf("WARN: unknown key '%s', formatted '%s' as string", tagPair[0], tagPair[1])
 	}
 
-	formated := fmt.Sprintf("\"%s\":%s", tagPair[0], tagPair[1])
+	formated := fmt.Sprintf("%s:%s", jsonString(tagPair[0]), tagPair[1])
 	b = append(b, formated...)
 	return b
 }
@@ -0,0 +1,44 @@
+package twitchgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIRCMessageTagsJSONRoundTrip(t *testing.T) {
+	const raw = `@badge-info=;badges=moderator/1,subscriber/12;bits=;color=#1E90FF;display-name=Ronni;emote-sets=0,33,50;id=b34ccfc7-4977-403a-8a94-33c6bac34fb8;mod=1;room-id=713936733;subscriber=1;tmi-sent-ts=1642715756806;turbo=0;user-id=1337;user-type=mod :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #channel :hello`
+
+	m, err := ParseMessage(raw)
+	if err != nil {
+		t.Fatalf("ParseMessage() error = %v", err)
+	}
+	tags := m.Tags
+
+	wantTimestamp := time.UnixMilli(1642715756806).UTC()
+	if !tags.Timestamp.Equal(wantTimestamp) {
+		t.Errorf("Timestamp = %v, want %v", tags.Timestamp, wantTimestamp)
+	}
+	if tags.BadgeInfo != nil {
+		t.Errorf("BadgeInfo = %q, want nil", tags.BadgeInfo)
+	}
+	if want := []string{"moderator/1", "subscriber/12"}; !reflect.DeepEqual(tags.Badges, want) {
+		t.Errorf("Badges = %q, want %q", tags.Badges, want)
+	}
+	if want := []string{"0", "33", "50"}; !reflect.DeepEqual(tags.EmoteSets, want) {
+		t.Errorf("EmoteSets = %q, want %q", tags.EmoteSets, want)
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got IRCMessageTags
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, tags) {
+		t.Errorf("round trip changed the tags\ngot:  %+v\nwant: %+v", got, tags)
+	}
+}
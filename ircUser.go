@@ -1,9 +1,30 @@
 package twitchgo
 
-// IRCUser represents the source user of a IRCMessage.
+// IRCUser represents the source user of a IRCMessage. For messages sent by the server itself,
+// only Host is set.
+//
+// On Twitch, the source of a user is in the form "<nick>!<nick>@<nick>.tmi.twitch.tv".
 type IRCUser struct {
 	Nickname string
+	Username string
 	Host     string
+
+	// UserID is the ID of the user. It is only set for chat messages, e.g. in the callback of
+	// [Session.OnChannelMessage].
+	UserID string
+	// DisplayName is the display name of the user, which may differ from Nickname in its casing or
+	// contain non-ASCII characters. If the user has no display name, it is the same as Nickname. Like
+	// UserID, it is only set for chat messages.
+	DisplayName string
+}
+
+// setChatTags sets the fields of u, which are only known from the tags of a chat message.
+func (u *IRCUser) setChatTags(tags IRCMessageTags) {
+	u.UserID = tags.UserID
+	u.DisplayName = tags.DisplayName
+	if u.DisplayName == "" {
+		u.DisplayName = u.Nickname
+	}
 }
 
 // String implements the [fmt.Stringer].
@@ -13,3 +34,12 @@ func (u IRCUser) String() string {
 	}
 	return u.Nickname
 }
+
+// Prefix returns the full source prefix in the form "<nick>!<user>@<host>", or only the host if the
+// message was sent by the server itself.
+func (u IRCUser) Prefix() string {
+	if u.Nickname == "" {
+		return u.Host
+	}
+	return u.Nickname + "!" + u.Username + "@" + u.Host
+}
@@ -0,0 +1,207 @@
+package twitchgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrJoinFailed is returned by [Session.JoinChannelAndWait], when Twitch refused to join the
+// channel.
+var ErrJoinFailed = errors.New("join failed")
+
+// joinFailureNotices are the msg-ids of notices Twitch sends, when joining a channel failed.
+var joinFailureNotices = map[NoticeType]bool{
+	NoticeMsgChannelSuspended: true,
+	NoticeMsgBanned:           true,
+	NoticeTosBan:              true,
+}
+
+// joinWaiters keeps track of the callers of [Session.JoinChannelAndWait] waiting for a
+// confirmation.
+type joinWaiters struct {
+	mu      sync.Mutex
+	waiters map[string][]chan error
+}
+
+func (w *joinWaiters) add(channel string, result chan error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.waiters == nil {
+		w.waiters = make(map[string][]chan error)
+	}
+	w.waiters[channel] = append(w.waiters[channel], result)
+}
+
+func (w *joinWaiters) remove(channel string, result chan error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	waiters := w.waiters[channel]
+	for i, r := range waiters {
+		if r == result {
+			w.waiters[channel] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(w.waiters[channel]) == 0 {
+		delete(w.waiters, channel)
+	}
+}
+
+// resolve sends err to all callers waiting for channel and removes them.
+func (w *joinWaiters) resolve(channel string, err error) {
+	w.mu.Lock()
+	waiters := w.waiters[channel]
+	delete(w.waiters, channel)
+	w.mu.Unlock()
+
+	for _, result := range waiters {
+		result <- err
+	}
+}
+
+// resolveAll sends err to all waiting callers and removes them.
+func (w *joinWaiters) resolveAll(err error) {
+	w.mu.Lock()
+	waiters := w.waiters
+	w.waiters = nil
+	w.mu.Unlock()
+
+	for _, results := range waiters {
+		for _, result := range results {
+			result <- err
+		}
+	}
+}
+
+// joinedChannels keeps track of the channels the bot is currently in.
+type joinedChannels struct {
+	mu       sync.Mutex
+	channels map[string]bool
+	// leaving are the channels the bot requested to leave with [Session.LeaveChannel]
+	leaving map[string]bool
+}
+
+func (c *joinedChannels) add(channel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.channels == nil {
+		c.channels = make(map[string]bool)
+	}
+	c.channels[channel] = true
+}
+
+// markLeaving remembers that the bot requested to leave channel.
+func (c *joinedChannels) markLeaving(channel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.leaving == nil {
+		c.leaving = make(map[string]bool)
+	}
+	c.leaving[channel] = true
+}
+
+// remove removes channel and reports whether the bot requested to leave it.
+func (c *joinedChannels) remove(channel string) (requested bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	requested = c.leaving[channel]
+	delete(c.channels, channel)
+	delete(c.leaving, channel)
+	return requested
+}
+
+func (c *joinedChannels) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.channels = nil
+	c.leaving = nil
+}
+
+// list returns the joined channels in alphabetical order.
+func (c *joinedChannels) list() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	channels := make([]string, 0, len(c.channels))
+	for channel := range c.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// NormalizeChannel returns the name of the channel in the form used throughout this package:
+// lowercase and without the leading "#", e.g. "#Foo" becomes "foo". The channels passed to the
+// callbacks, like the one of [Session.OnChannelMessage], are always normalized. All methods taking
+// a channel accept it in any form.
+func NormalizeChannel(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
+}
+
+// Channels returns the names of all channels the bot is currently in, without the leading "#".
+func (s *Session) Channels() []string {
+	return s.channels.list()
+}
+
+// JoinChannelAndWait joins the given channel like [Session.JoinChannel], but blocks until Twitch
+// confirmed the join. It returns an error wrapping [ErrJoinFailed], if Twitch refused to join the
+// channel, or the error of ctx, if ctx is done before.
+func (s *Session) JoinChannelAndWait(ctx context.Context, channel string) error {
+	channel = NormalizeChannel(channel)
+	result := make(chan error, 1)
+	s.joinWaiters.add(channel, result)
+	defer s.joinWaiters.remove(channel, result)
+
+	s.JoinChannel(channel)
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleJoinConfirmation resolves the callers of [Session.JoinChannelAndWait], if m confirms or
+// refuses a join. Twitch sends a ROOMSTATE message after the bot successfully joined a channel.
+func (s *Session) handleJoinConfirmation(m *IRCMessage) {
+	if len(m.Command.Arguments) == 0 {
+		return
+	}
+
+	var err error
+	switch m.Command.Name {
+	case IRCMsgCmdRoomstate:
+	case IRCMsgCmdNotice:
+		if !joinFailureNotices[NoticeType(m.Tags.MsgType)] {
+			return
+		}
+		err = fmt.Errorf("%w: %s", ErrJoinFailed, m.Command.Data)
+	default:
+		return
+	}
+
+	channel := NormalizeChannel(m.Command.Arguments[0])
+	s.joinWaiters.resolve(channel, err)
+}
+
+// trackChannels updates the joined channels, if m is a JOIN or PART of the bot itself. A PART the
+// bot did not request with [Session.LeaveChannel] means the bot was banned from the channel.
+func (s *Session) trackChannels(m *IRCMessage) {
+	if m.Source == nil || m.Source.Nickname != s.login || len(m.Command.Arguments) == 0 {
+		return
+	}
+
+	channel := NormalizeChannel(m.Command.Arguments[0])
+	switch m.Command.Name {
+	case IRCMsgCmdJoin:
+		s.channels.add(channel)
+	case IRCMsgCmdPart:
+		if !s.channels.remove(channel) {
+			s.handleBannedFromChannel(m, channel)
+		}
+	}
+}
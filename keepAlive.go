@@ -0,0 +1,67 @@
+package twitchgo
+
+import (
+	"log"
+	"net"
+	"time"
+)
+
+// maxReconnectDelay is the maximum time to wait between two reconnect attempts.
+const maxReconnectDelay = 2 * time.Minute
+
+// SetKeepAlive enables a health check of the connection to the Twitch IRC server. When nothing was
+// received for interval, a PING is sent to the server. If there is still no answer after timeout,
+// the connection is considered dead. The connection is also considered dead on any read error.
+//
+// When the connection is dead, the Session reconnects automatically, waiting longer after every
+// failed attempt, and joins all previously joined channels again. Reconnecting stops when
+// [Session.Close] is called.
+//
+// The keep-alive check is disabled by default. Setting interval to 0 disables it. If timeout is not
+// positive, interval is used as timeout. The settings are applied on the next call to
+// [Session.Connect].
+func (s *Session) SetKeepAlive(interval, timeout time.Duration) *Session {
+	if timeout <= 0 {
+		timeout = interval
+	}
+	s.keepAliveInterval = interval
+	s.keepAliveTimeout = timeout
+	return s
+}
+
+// reconnect replaces the dead connection conn with a new one and joins the channels again.
+func (s *Session) reconnect(conn net.Conn) {
+	s.mu.Lock()
+	if s.ircConn != conn {
+		// the connection was closed or replaced already
+		s.mu.Unlock()
+		return
+	}
+	channels := s.Channels()
+	conn.Close()
+	s.reset()
+	s.mu.Unlock()
+
+	delay := time.Second
+	for {
+		s.mu.Lock()
+		if s.closed || s.ircConn != nil {
+			s.mu.Unlock()
+			return
+		}
+		log.Print("Reconnecting to Twitch...")
+		err := s.connect()
+		s.mu.Unlock()
+		if err == nil {
+			break
+		}
+
+		log.Printf("Reconnect failed, retrying in %s: %v", delay, err)
+		time.Sleep(delay)
+		delay = min(2*delay, maxReconnectDelay)
+	}
+
+	if len(channels) > 0 {
+		s.JoinChannels(channels...)
+	}
+}
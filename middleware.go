@@ -0,0 +1,30 @@
+package twitchgo
+
+// Handler handles a message received from the Twitch IRC server.
+type Handler func(s *Session, m *IRCMessage)
+
+// Middleware wraps a Handler to run code before or after it. A middleware can drop a message by
+// not calling next.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware to the dispatch pipeline of received messages. The middlewares are called in
+// the order they were added, the last one calls the registered callbacks like the ones of
+// [Session.OnChannelMessage]. This is useful for cross-cutting concerns like logging, metrics or
+// spam filtering.
+//
+// Replying to PING messages and keeping track of joined channels happens before the middlewares
+// are called, so dropping a message does not affect the connection.
+func (s *Session) Use(middleware Middleware) *Session {
+	s.middlewares = append(s.middlewares, middleware)
+	return s
+}
+
+// dispatchHandler returns the handler calling all the registered callbacks, wrapped by the
+// middlewares.
+func (s *Session) dispatchHandler() Handler {
+	h := Handler(dispatch)
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		h = s.middlewares[i](h)
+	}
+	return h
+}
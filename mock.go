@@ -0,0 +1,114 @@
+package twitchgo
+
+import (
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockLogin is the login name of the bot of a Session created by [NewMock]. Use it as the source of
+// fed messages, which should look like they were sent by the bot itself, e.g. a JOIN.
+const MockLogin = "twitchgo_mock"
+
+// MockTransport replaces the connection to the Twitch IRC server of a Session created by
+// [NewMock]. It feeds raw IRC lines to the Session and captures all commands the Session sends.
+type MockTransport struct {
+	s    *Session
+	conn *mockConn
+}
+
+// NewMock creates a Session, which behaves like it is connected to the Twitch IRC server, but
+// never touches the network. Use the returned [MockTransport] to feed messages to the Session and
+// to inspect the commands it sends. This is useful to unit test callbacks like the ones registered
+// with [Session.OnChannelCommandMessage].
+//
+// The bot of the Session has the login name [MockLogin]. The Session has no API access.
+func NewMock() (*Session, *MockTransport) {
+	s := NewIRCOnly("oauth:mock")
+	conn := &mockConn{closed: make(chan struct{})}
+	s.ircConn = conn
+	s.ircReader = newLineReader(conn, s.readBufferSize())
+	s.writer.start(s, conn)
+	s.login = MockLogin
+	s.self = &IRCMessageTags{Login: MockLogin, DisplayName: MockLogin}
+
+	s.listenDone = make(chan struct{})
+	go listen(s)
+	return s, &MockTransport{s: s, conn: conn}
+}
+
+// Feed passes the given raw IRC lines to the Session, as if they were sent by the Twitch IRC
+// server. The lines are handled synchronously, so all callbacks have returned when Feed returns.
+// The lines may end with "\r\n", but don't have to.
+func (m *MockTransport) Feed(lines ...string) {
+	for _, line := range lines {
+		m.s.Inject(line)
+	}
+}
+
+// Sent returns all commands the Session sent so far in the order they were sent, without the
+// trailing "\r\n".
+func (m *MockTransport) Sent() []string {
+	m.conn.mu.Lock()
+	defer m.conn.mu.Unlock()
+	return append([]string{}, m.conn.sent...)
+}
+
+// ClearSent forgets all commands the Session sent so far.
+func (m *MockTransport) ClearSent() {
+	m.conn.mu.Lock()
+	defer m.conn.mu.Unlock()
+	m.conn.sent = nil
+}
+
+// mockConn is the [net.Conn] used by [MockTransport]. It records all written commands. Reading
+// blocks until the connection is closed, because fed lines bypass the connection. Like the Twitch
+// IRC server, it closes the connection when receiving a QUIT command.
+type mockConn struct {
+	mu        sync.Mutex
+	sent      []string
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *mockConn) Read(b []byte) (int, error) {
+	<-c.closed
+	return 0, io.EOF
+}
+
+func (c *mockConn) Write(b []byte) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	c.mu.Lock()
+	for _, cmd := range strings.Split(strings.TrimSuffix(string(b), "\r\n"), "\r\n") {
+		c.sent = append(c.sent, cmd)
+	}
+	c.mu.Unlock()
+
+	if strings.HasPrefix(string(b), string(IRCMsgCmdQuit)) {
+		c.Close()
+	}
+	return len(b), nil
+}
+
+func (c *mockConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *mockConn) LocalAddr() net.Addr                { return mockAddr{} }
+func (c *mockConn) RemoteAddr() net.Addr               { return mockAddr{} }
+func (c *mockConn) SetDeadline(t time.Time) error      { return nil }
+func (c *mockConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *mockConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type mockAddr struct{}
+
+func (mockAddr) Network() string { return "mock" }
+func (mockAddr) String() string  { return "mock" }
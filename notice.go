@@ -0,0 +1,90 @@
+package twitchgo
+
+// NoticeType is the msg-id of a NOTICE message. It identifies the kind of notice, e.g. whether a
+// command succeeded or why it failed. See "https://dev.twitch.tv/docs/irc/msg-id/" for a list of
+// all possible IDs.
+type NoticeType string
+
+const (
+	// NoticeAlreadyBanned is sent when the user is already banned in the channel.
+	NoticeAlreadyBanned NoticeType = "already_banned"
+	// NoticeAlreadyEmoteOnlyOff is sent when the room is not in emote-only mode.
+	NoticeAlreadyEmoteOnlyOff NoticeType = "already_emote_only_off"
+	// NoticeAlreadyEmoteOnlyOn is sent when the room is already in emote-only mode.
+	NoticeAlreadyEmoteOnlyOn NoticeType = "already_emote_only_on"
+	// NoticeAlreadyFollowersOff is sent when the room is not in followers-only mode.
+	NoticeAlreadyFollowersOff NoticeType = "already_followers_off"
+	// NoticeAlreadySubsOff is sent when the room is not in subscribers-only mode.
+	NoticeAlreadySubsOff NoticeType = "already_subs_off"
+	// NoticeAlreadySubsOn is sent when the room is already in subscribers-only mode.
+	NoticeAlreadySubsOn NoticeType = "already_subs_on"
+	// NoticeBadBanSelf is sent when the user tried to ban themselves.
+	NoticeBadBanSelf NoticeType = "bad_ban_self"
+	// NoticeBadUnbanNoBan is sent when the user to unban is not banned.
+	NoticeBadUnbanNoBan NoticeType = "bad_unban_no_ban"
+	// NoticeBanSuccess is sent when a user was banned.
+	NoticeBanSuccess NoticeType = "ban_success"
+	// NoticeEmoteOnlyOff is sent when the room is no longer in emote-only mode.
+	NoticeEmoteOnlyOff NoticeType = "emote_only_off"
+	// NoticeEmoteOnlyOn is sent when the room is now in emote-only mode.
+	NoticeEmoteOnlyOn NoticeType = "emote_only_on"
+	// NoticeFollowersOff is sent when the room is no longer in followers-only mode.
+	NoticeFollowersOff NoticeType = "followers_off"
+	// NoticeFollowersOn is sent when the room is now in followers-only mode.
+	NoticeFollowersOn NoticeType = "followers_on"
+	// NoticeFollowersOnZero is sent when the room is now in followers-only mode without a minimum
+	// follow duration.
+	NoticeFollowersOnZero NoticeType = "followers_on_zero"
+	// NoticeMsgBanned is sent when the bot is banned from talking in the channel.
+	NoticeMsgBanned NoticeType = "msg_banned"
+	// NoticeMsgChannelSuspended is sent when joining a channel, which is suspended.
+	NoticeMsgChannelSuspended NoticeType = "msg_channel_suspended"
+	// NoticeMsgDuplicate is sent when the message was not sent, because it is identical to the
+	// previous message sent less than 30 seconds ago.
+	NoticeMsgDuplicate NoticeType = "msg_duplicate"
+	// NoticeMsgEmoteOnly is sent when the message was not sent, because the room is in emote-only
+	// mode.
+	NoticeMsgEmoteOnly NoticeType = "msg_emoteonly"
+	// NoticeMsgFollowersOnly is sent when the message was not sent, because the room is in
+	// followers-only mode.
+	NoticeMsgFollowersOnly NoticeType = "msg_followersonly"
+	// NoticeMsgRatelimit is sent when the message was not sent, because the bot is sending messages
+	// too quickly.
+	NoticeMsgRatelimit NoticeType = "msg_ratelimit"
+	// NoticeMsgRejected is sent when the message was held back by AutoMod.
+	NoticeMsgRejected NoticeType = "msg_rejected"
+	// NoticeMsgRejectedMandatory is sent when the message was not sent, because it contained a
+	// word that the channel blocks.
+	NoticeMsgRejectedMandatory NoticeType = "msg_rejected_mandatory"
+	// NoticeMsgSlowMode is sent when the message was not sent, because the room is in slow mode and
+	// the bot sent a message recently.
+	NoticeMsgSlowMode NoticeType = "msg_slowmode"
+	// NoticeMsgSubsOnly is sent when the message was not sent, because the room is in
+	// subscribers-only mode.
+	NoticeMsgSubsOnly NoticeType = "msg_subsonly"
+	// NoticeMsgSuspended is sent when the message was not sent, because the bot account is
+	// suspended.
+	NoticeMsgSuspended NoticeType = "msg_suspended"
+	// NoticeMsgTimedOut is sent when the message was not sent, because the bot is timed out.
+	NoticeMsgTimedOut NoticeType = "msg_timedout"
+	// NoticeMsgVerifiedEmail is sent when the message was not sent, because the room requires a
+	// verified email address.
+	NoticeMsgVerifiedEmail NoticeType = "msg_verified_email"
+	// NoticeSlowOff is sent when the room is no longer in slow mode.
+	NoticeSlowOff NoticeType = "slow_off"
+	// NoticeSlowOn is sent when the room is now in slow mode.
+	NoticeSlowOn NoticeType = "slow_on"
+	// NoticeSubsOff is sent when the room is no longer in subscribers-only mode.
+	NoticeSubsOff NoticeType = "subs_off"
+	// NoticeSubsOn is sent when the room is now in subscribers-only mode.
+	NoticeSubsOn NoticeType = "subs_on"
+	// NoticeTimeoutSuccess is sent when a user was timed out.
+	NoticeTimeoutSuccess NoticeType = "timeout_success"
+	// NoticeTosBan is sent when joining a channel, which was banned for violating the Terms of
+	// Service.
+	NoticeTosBan NoticeType = "tos_ban"
+	// NoticeUnbanSuccess is sent when a user was unbanned.
+	NoticeUnbanSuccess NoticeType = "unban_success"
+	// NoticeUnrecognizedCmd is sent when an unrecognized command was used.
+	NoticeUnrecognizedCmd NoticeType = "unrecognized_cmd"
+)
@@ -1,16 +1,21 @@
 package oauth
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// ErrMissingRedirectURI is returned by the authorization code flow, when neither a redirect URI
+// was passed nor [Client.RedirectURI] is set.
+var ErrMissingRedirectURI = errors.New("missing redirect uri")
+
 // Client is the data struct for a auth client
 type Client struct {
 	RequestURL   string    `json:"request_url"`
@@ -19,6 +24,11 @@ type Client struct {
 	Scope        string    `json:"scope"`
 	ExpiryDate   time.Time `json:"expiry_date"`
 
+	// RedirectURI is the default redirect URI used by the authorization code flow, if no other URI
+	// is passed. It has to match one of the redirect URIs registered for the application.
+	RedirectURI string `json:"redirect_uri"`
+
+	mu        sync.Mutex
 	lastToken Token
 }
 
@@ -48,11 +58,48 @@ func New(requestURL, clientID, secret, scope string) *Client {
 // clears the latest saved token so the next call to [Client.GenerateToken] uses the new
 // refreshToken.
 func (c *Client) SetRefreshToken(refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.lastToken = Token{RefreshToken: refreshToken}
 }
 
-// GenerateToken generates and returns a new token for c
+// RefreshToken returns the refresh token of the latest generated token. Twitch may rotate the
+// refresh token on every refresh, so applications should persist the returned value after
+// generating a token.
+func (c *Client) RefreshToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastToken.RefreshToken
+}
+
+// HasScope reports whether the latest generated token was granted the given scope. It always
+// reports false, if no token was generated yet.
+func (c *Client) HasScope(scope string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range c.lastToken.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidate marks the cached token as expired, so the next call to [Client.GenerateToken]
+// generates a new token, even if the cached one did not expire yet. The refresh token is kept.
+func (c *Client) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastToken.expiresAt = time.Time{}
+}
+
+// GenerateToken generates and returns a new token for c. It is safe for concurrent use. The token
+// is cached until it expires.
 func (c *Client) GenerateToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.lastToken.expiresAt.After(time.Now()) {
 		return c.lastToken.Token, nil
 	}
@@ -74,7 +121,8 @@ func (c *Client) generateFromCredentials() (string, error) {
 	}
 
 	body := strings.NewReader(form.Encode())
-	return c.tokenRequest(body)
+	t, err := c.tokenRequest(body)
+	return t.Token, err
 }
 
 func (c *Client) generateFromRefreshToken() (string, error) {
@@ -85,51 +133,30 @@ func (c *Client) generateFromRefreshToken() (string, error) {
 	form.Set("refresh_token", c.lastToken.RefreshToken)
 
 	body := strings.NewReader(form.Encode())
-	return c.tokenRequest(body)
-}
-
-func (c *Client) generateFromAuthorizationCode(code string) (string, error) {
-	authCodeBody := struct {
-		ClientID          string `json:"client_id"`
-		ClientSecret      string `json:"client_secret"`
-		AuthorizationCode string `json:"code"`
-		GrantType         string `json:"grant_type"`   // always "authorization_code"
-		RedirectURI       string `json:"redirect_uri"` // always "https://webhook.cake4everyone.de/auth/twitch"
-	}{
-		ClientID:          c.ClientID,
-		ClientSecret:      c.ClientSecret,
-		AuthorizationCode: code,
-		GrantType:         "authorization_code",
-		RedirectURI:       "https://webhook.cake4everyone.de/auth/twitch",
-	}
-
-	rawBody, err := json.Marshal(authCodeBody)
-	if err != nil {
-		panic(err)
-	}
-	body := bytes.NewReader(rawBody)
-
-	return c.tokenRequest(body)
+	t, err := c.tokenRequest(body)
+	return t.Token, err
 }
 
-func (c *Client) tokenRequest(body io.Reader) (string, error) {
+// tokenRequest requests a new token and caches it. c.mu must be held by the caller.
+func (c *Client) tokenRequest(body io.Reader) (Token, error) {
 	req, err := http.NewRequest(http.MethodPost, c.RequestURL, body)
 	if err != nil {
-		return "", err
+		return Token{}, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", err
+		return Token{}, err
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return Token{}, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("invalid status code expected %d but got %d! body: %s", http.StatusOK, resp.StatusCode, string(data))
+		return Token{}, fmt.Errorf("invalid status code expected %d but got %d! body: %s", http.StatusOK, resp.StatusCode, string(data))
 	}
 
 	var t Token
@@ -137,5 +164,13 @@ func (c *Client) tokenRequest(body io.Reader) (string, error) {
 	t.expiresAt = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
 	c.lastToken = t
 
-	return t.Token, err
+	return t, err
+}
+
+// endpointURL returns the URL of the endpoint with the given name. The endpoint is expected to be
+// next to the token endpoint of RequestURL, e.g. "https://id.twitch.tv/oauth2/authorize" for the
+// token endpoint "https://id.twitch.tv/oauth2/token".
+func (c *Client) endpointURL(name string) string {
+	i := strings.LastIndex(c.RequestURL, "/")
+	return c.RequestURL[:i+1] + name
 }
@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AuthCodeURL returns the URL to the consent page, where the user authorizes the application with
+// the given scopes. After authorizing, the user is redirected to redirectURI with the query
+// parameters "code" and "state". The redirectURI has to match one of the redirect URIs registered
+// for the application. If redirectURI is empty, [Client.RedirectURI] is used.
+//
+// state should be a random string, which is then used to verify that the redirect belongs to this
+// request.
+func (c *Client) AuthCodeURL(redirectURI, state string, scopes []string) string {
+	if redirectURI == "" {
+		redirectURI = c.RedirectURI
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(scopes, " "))
+	if state != "" {
+		query.Set("state", state)
+	}
+	return c.endpointURL("authorize") + "?" + query.Encode()
+}
+
+// ExchangeCode exchanges the authorization code, received by the redirect after the user
+// authorized the application, for a user access token. The redirectURI has to be the same as used
+// in [Client.AuthCodeURL]. If redirectURI is empty, [Client.RedirectURI] is used. If that is also
+// empty, [ErrMissingRedirectURI] is returned.
+//
+// The token is cached and later refreshed with its refresh token by [Client.GenerateToken].
+func (c *Client) ExchangeCode(code, redirectURI string) (Token, error) {
+	if redirectURI == "" {
+		redirectURI = c.RedirectURI
+	}
+	if redirectURI == "" {
+		return Token{}, ErrMissingRedirectURI
+	}
+
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	form.Set("code", code)
+	form.Set("grant_type", "authorization_code")
+	form.Set("redirect_uri", redirectURI)
+
+	body := strings.NewReader(form.Encode())
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokenRequest(body)
+}
+
+// WaitForCode starts a local HTTP server listening on addr (e.g. "localhost:3000") and waits for
+// the redirect after the user authorized the application. The redirect URI passed to
+// [Client.AuthCodeURL] has to point to this server. It returns the authorization code, which can
+// then be passed to [Client.ExchangeCode].
+//
+// The state query parameter of the redirect has to match state. WaitForCode returns when the
+// redirect was received or ctx is done.
+func (c *Client) WaitForCode(ctx context.Context, addr, state string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		var res result
+		if errMsg := query.Get("error"); errMsg != "" {
+			res.err = fmt.Errorf("authorization failed: %s: %s", errMsg, query.Get("error_description"))
+			http.Error(w, "Authorization failed. You can close this window.", http.StatusForbidden)
+		} else if res.code = query.Get("code"); res.code == "" {
+			http.Error(w, "missing code", http.StatusBadRequest)
+			return
+		} else {
+			fmt.Fprint(w, "Authorization successful. You can close this window.")
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case res := <-results:
+		return res.code, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
@@ -0,0 +1,50 @@
+package oauth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGenerateTokenConcurrent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token%d","expires_in":3600,"scope":["chat:read"]}`, n)
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/oauth2/token", "id", "secret", "")
+
+	const goroutines = 50
+	tokens := make([]string, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := c.GenerateToken()
+			if err != nil {
+				t.Errorf("GenerateToken() error = %v", err)
+				return
+			}
+			tokens[i] = token
+			c.HasScope("chat:read")
+			c.RefreshToken()
+		}(i)
+	}
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d token requests, want 1", n)
+	}
+	for i, token := range tokens {
+		if token != "token1" {
+			t.Errorf("token %d = %q, want %q", i, token, "token1")
+		}
+	}
+}
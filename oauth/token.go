@@ -0,0 +1,93 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidToken is returned by [Client.Validate], when the token is invalid, expired or revoked.
+var ErrInvalidToken = errors.New("invalid access token")
+
+// ValidateResult is the response of a successfull token validation.
+type ValidateResult struct {
+	// The ID of the client the token was generated for.
+	ClientID string `json:"client_id"`
+	// The login name of the user the token belongs to. Is empty for app access tokens.
+	Login string `json:"login"`
+	// The scopes the token was granted.
+	Scopes []string `json:"scopes"`
+	// The ID of the user the token belongs to. Is empty for app access tokens.
+	UserID string `json:"user_id"`
+	// The number of seconds until the token expires.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// Validate validates the given access token. Twitch requires applications to validate user access
+// tokens on startup and hourly afterwards.
+//
+// Returns [ErrInvalidToken], if the token is no longer valid.
+func (c *Client) Validate(token string) (*ValidateResult, error) {
+	req, err := http.NewRequest(http.MethodGet, c.endpointURL("validate"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "OAuth "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrInvalidToken
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code expected %d but got %d! body: %s", http.StatusOK, resp.StatusCode, string(data))
+	}
+
+	var result ValidateResult
+	err = json.Unmarshal(data, &result)
+	return &result, err
+}
+
+// Revoke revokes the given access token, so it can no longer be used. If token is the cached token
+// of c, the cache is cleared on success.
+func (c *Client) Revoke(token string) error {
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	form.Set("token", token)
+
+	req, err := http.NewRequest(http.MethodPost, c.endpointURL("revoke"), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("invalid status code expected %d but got %d! body: %s", http.StatusOK, resp.StatusCode, string(data))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastToken.Token == token {
+		c.lastToken = Token{}
+	}
+	return nil
+}
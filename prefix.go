@@ -0,0 +1,106 @@
+package twitchgo
+
+import (
+	"strings"
+	"sync"
+)
+
+// channelPrefixes holds the command prefixes set for single channels.
+type channelPrefixes struct {
+	mu       sync.Mutex
+	prefixes map[string]string
+}
+
+func (p *channelPrefixes) get(channel string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	prefix, ok := p.prefixes[channel]
+	return prefix, ok
+}
+
+func (p *channelPrefixes) set(channel, prefix string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if prefix == "" {
+		delete(p.prefixes, channel)
+		return
+	}
+	if p.prefixes == nil {
+		p.prefixes = make(map[string]string)
+	}
+	p.prefixes[channel] = prefix
+}
+
+// SetPrefixes sets all the command prefixes accepted by [Session.OnChannelCommandMessage], e.g.
+// "!" and "~". When a message starts with multiple of the prefixes, the longest one is stripped.
+// Calling SetPrefixes without any prefix accepts only s.Prefix again, which is also the default.
+//
+// Channels with their own prefix set by [Session.SetChannelPrefix] only accept that prefix. See
+// [Session.SetMentionCommands] to also accept commands addressed to the bot by mentioning it.
+func (s *Session) SetPrefixes(prefixes ...string) *Session {
+	s.prefixMu.Lock()
+	defer s.prefixMu.Unlock()
+	s.prefixes = append([]string{}, prefixes...)
+	return s
+}
+
+// SetMentionCommands sets whether commands addressed to the bot by mentioning it are accepted by
+// [Session.OnChannelCommandMessage] in addition to the prefixes, e.g. the message "@botname foo
+// bar" translates to the command "foo" with the argument "bar". Mention commands are disabled by
+// default.
+func (s *Session) SetMentionCommands(enabled bool) *Session {
+	s.prefixMu.Lock()
+	defer s.prefixMu.Unlock()
+	s.mentionCommands = enabled
+	return s
+}
+
+// SetChannelPrefix sets the command prefix used by [Session.OnChannelCommandMessage] in the given
+// channel. Channels without their own prefix use the global prefixes, see [Session.SetPrefixes].
+// Setting prefix to an empty string removes the channel’s prefix, so the global ones are used
+// again.
+func (s *Session) SetChannelPrefix(channel, prefix string) *Session {
+	s.channelPrefixes.set(NormalizeChannel(channel), prefix)
+	return s
+}
+
+// commandPrefixes returns the command prefixes accepted in the given channel.
+func (s *Session) commandPrefixes(channel string) []string {
+	if prefix, ok := s.channelPrefixes.get(NormalizeChannel(channel)); ok {
+		return []string{prefix}
+	}
+
+	s.prefixMu.Lock()
+	defer s.prefixMu.Unlock()
+	if len(s.prefixes) == 0 {
+		return []string{s.Prefix}
+	}
+	return s.prefixes
+}
+
+// cutCommand splits msg into the command name and its arguments. ok is false, if msg is not a
+// command in the given channel.
+func (s *Session) cutCommand(channel, msg string) (cmd string, args []string, ok bool) {
+	args = strings.Split(msg, " ")
+
+	s.prefixMu.Lock()
+	mentionCommands := s.mentionCommands
+	s.prefixMu.Unlock()
+	if mentionCommands && len(args) > 1 && s.login != "" {
+		mention, isMention := strings.CutPrefix(strings.TrimSuffix(args[0], ","), "@")
+		if isMention && strings.EqualFold(mention, s.login) {
+			return args[1], args[2:], true
+		}
+	}
+
+	var prefix string
+	for _, p := range s.commandPrefixes(channel) {
+		if strings.HasPrefix(args[0], p) && (!ok || len(p) > len(prefix)) {
+			prefix, ok = p, true
+		}
+	}
+	if !ok {
+		return "", nil, false
+	}
+	return strings.TrimPrefix(args[0], prefix), args[1:], true
+}
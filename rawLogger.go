@@ -0,0 +1,59 @@
+package twitchgo
+
+import (
+	"log"
+	"strings"
+)
+
+// Direction is the direction of a raw IRC line passed to the logger set by
+// [Session.SetRawLogger].
+type Direction int
+
+const (
+	// DirectionOutbound is used for lines sent to the Twitch IRC server.
+	DirectionOutbound Direction = iota
+	// DirectionInbound is used for lines received from the Twitch IRC server.
+	DirectionInbound
+)
+
+// String returns "<<" for outbound and ">>" for inbound lines.
+func (d Direction) String() string {
+	if d == DirectionInbound {
+		return ">>"
+	}
+	return "<<"
+}
+
+// RawLogger receives every raw IRC line sent to or received from the Twitch IRC server, without
+// the trailing "\r\n".
+type RawLogger func(direction Direction, line string)
+
+// defaultRawLogger logs only outbound lines.
+func defaultRawLogger(direction Direction, line string) {
+	if direction == DirectionOutbound {
+		log.Printf("%s %s", direction, line)
+	}
+}
+
+// SetRawLogger sets the function called with every raw IRC line sent to or received from the
+// Twitch IRC server. Inbound lines are passed before they are parsed. The token of the PASS
+// command is always redacted.
+//
+// By default, only outbound lines are logged with the standard logger. Setting logger to nil
+// disables the logging.
+func (s *Session) SetRawLogger(logger RawLogger) *Session {
+	s.rawLogger = logger
+	return s
+}
+
+// logRaw passes line to the raw logger of s, if set.
+func (s *Session) logRaw(direction Direction, line string) {
+	if s.rawLogger == nil {
+		return
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if strings.HasPrefix(line, string(IRCMsgCmdPass)+" ") {
+		line = string(IRCMsgCmdPass) + " ***"
+	}
+	s.rawLogger(direction, line)
+}
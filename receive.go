@@ -1,13 +1,23 @@
 package twitchgo
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"log"
 	"net"
+	"os"
+	"runtime/debug"
 	"strings"
 	"time"
 )
 
+// ErrMalformedMessage is passed to the callbacks registered with [Session.OnParseError], when a
+// message received from the Twitch IRC server could not be parsed.
+var ErrMalformedMessage = errors.New("malformed IRC message")
+
 // waitForInit waits up to 5 seconds for a login response from the Twitch IRC server.
 func waitForInit(s *Session) (err error) {
 	s.ircConn.SetReadDeadline(time.Now().Add(5 * time.Second))
@@ -15,13 +25,31 @@ func waitForInit(s *Session) (err error) {
 
 	for {
 		var buf []byte
-		buf, err = readAll(s.ircConn)
+		buf, err = s.ircReader.readAll()
 		if err != nil {
 			return err
 		}
 		for _, raw := range strings.Split(string(buf), "\r\n") {
-			m := parseMessage(raw)
-			if m.Command.Name == IRCMsgCmdGlobaluserstate {
+			if raw != "" {
+				s.logRaw(DirectionInbound, raw)
+			}
+			m, err := ParseMessage(raw)
+			if err != nil {
+				s.handleParseError(raw, err)
+			}
+			if m == nil {
+				continue
+			}
+			if m.Command.Name == "001" && len(m.Command.Arguments) > 0 {
+				// the welcome message is addressed to the login name of the bot
+				s.login = m.Command.Arguments[0]
+			} else if m.Command.Name == IRCMsgCmdGlobaluserstate {
+				self := m.Tags
+				self.Login = s.login
+				s.stateMu.Lock()
+				s.self = &self
+				s.stateMu.Unlock()
+				m.handle(s)
 				return nil
 			} else if m.Command.Name == IRCMsgCmdNotice && m.Command.Data == "Improperly formatted auth" {
 				return ErrInvalidToken
@@ -31,7 +59,7 @@ func waitForInit(s *Session) (err error) {
 }
 
 func parseInitMessage(s *Session, raw string) (byte, error) {
-	m := parseMessage(raw)
+	m, _ := ParseMessage(raw)
 	if m == nil {
 		return 0, nil
 	}
@@ -62,59 +90,170 @@ func parseInitMessage(s *Session, raw string) (byte, error) {
 	}
 }
 
+// keepAlivePing is the argument of the PING commands sent by the keep-alive check.
+const keepAlivePing = "twitchgo"
+
 func listen(s *Session) {
+	conn, reader, done := s.ircConn, s.ircReader, s.listenDone
+	interval, timeout := s.keepAliveInterval, s.keepAliveTimeout
+
+	var pinged, dead bool
 	for {
-		buf, err := readAll(s.ircConn)
+		if interval > 0 {
+			if pinged {
+				conn.SetReadDeadline(time.Now().Add(timeout))
+			} else {
+				conn.SetReadDeadline(time.Now().Add(interval))
+			}
+		}
+
+		buf, err := reader.readAll()
 		if errors.Is(err, net.ErrClosed) {
 			break
+		} else if err != nil && s.quitSent.Load() {
+			// the connection was closed after the QUIT sent by Close, e.g. with an EOF by the server
+			break
+		} else if errors.Is(err, os.ErrDeadlineExceeded) && !pinged {
+			// nothing received for a while, check if the server is still there
+			pinged = true
+			s.SendCommandf("%s :%s", IRCMsgCmdPing, keepAlivePing)
+			continue
 		} else if err != nil {
+			log.Printf("Twitch connection lost: %v", err)
+			dead = true
 			break
 		}
-		msgs := strings.Split(string(buf), "\r\n")
-		for _, m := range msgs {
-			parseMessage(m).handle(s)
-		}
+		pinged = false
+		s.Inject(string(buf))
+	}
+	close(done)
+
+	if dead && interval > 0 {
+		go s.reconnect(conn)
 	}
 }
 
-func readAll(conn net.Conn) ([]byte, error) {
-	buf := make([]byte, 0)
-	b := make([]byte, 1024)
-	for {
-		n, err := conn.Read(b)
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return []byte{}, err
-		}
-		buf = append(buf, b[:n]...)
-		if buf[len(buf)-1] == '\n' {
-			break
-		}
+// Inject passes raw to the Session, as if it was received from the Twitch IRC server. raw can
+// contain multiple lines separated by "\r\n". The lines are parsed and all registered callbacks
+// are called synchronously, so they all have returned when Inject returns.
+//
+// This is useful to test callbacks against recorded chat logs. See also [NewMock].
+func (s *Session) Inject(raw string) {
+	for _, line := range strings.Split(raw, "\r\n") {
+		s.handleRaw(line)
+	}
+}
+
+// handleRaw parses a single raw IRC line and calls all the registered callbacks for it.
+func (s *Session) handleRaw(raw string) {
+	if raw == "" {
+		return
+	}
+	s.logRaw(DirectionInbound, raw)
+	m, err := ParseMessage(raw)
+	if err != nil {
+		s.stats.parseErrors.Add(1)
+		s.handleParseError(raw, err)
+	}
+	if m != nil {
+		s.stats.countReceived(m.Command.Name)
+	}
+	m.handle(s)
+}
+
+// defaultReadBufferSize is the size of the buffer for reading from the Twitch IRC server, if not
+// set by [Session.SetReadBufferSize].
+const defaultReadBufferSize = 4096
+
+// SetReadBufferSize sets the size in bytes of the buffer used for reading from the Twitch IRC
+// server. A larger buffer reduces the number of reads for bots in channels with lots of messages
+// per second. Lines longer than the buffer are still read completely.
+//
+// The default size is 4096 bytes. Setting size to 0 restores the default. The size is applied on
+// the next call to [Session.Connect].
+func (s *Session) SetReadBufferSize(size int) *Session {
+	s.readBufSize = size
+	return s
+}
+
+// readBufferSize returns the size of the read buffer for a new connection.
+func (s *Session) readBufferSize() int {
+	if s.readBufSize <= 0 {
+		return defaultReadBufferSize
+	}
+	return s.readBufSize
+}
+
+// lineReader reads complete lines from the connection to the Twitch IRC server.
+type lineReader struct {
+	r *bufio.Reader
+	// partial is the start of a line, which was interrupted by an error like an exceeded deadline
+	partial []byte
+}
+
+func newLineReader(conn io.Reader, size int) *lineReader {
+	return &lineReader{r: bufio.NewReaderSize(conn, size)}
+}
+
+// readAll reads at least one complete line. All further complete lines, which are already
+// buffered, are returned too, so a burst of messages does not need a read per line. A line
+// interrupted by an error is kept and completed by the next call.
+func (l *lineReader) readAll() ([]byte, error) {
+	r := l.r
+	line, err := r.ReadBytes('\n')
+	buf := append(l.partial, line...)
+	l.partial = nil
+	if err == io.EOF && len(buf) > 0 {
+		return buf, nil
+	} else if err != nil {
+		l.partial = buf
+		return []byte{}, err
+	}
+
+	buffered, _ := r.Peek(r.Buffered())
+	if i := bytes.LastIndexByte(buffered, '\n'); i >= 0 {
+		buf = append(buf, buffered[:i+1]...)
+		r.Discard(i + 1)
 	}
 	return buf, nil
 }
 
-func parseMessage(raw string) *IRCMessage {
+// ParseMessage parses a single raw IRC line without the trailing "\r\n". It returns an error
+// wrapping [ErrMalformedMessage], if the line is not a valid IRC message. If only the tags could
+// not be parsed, the message is still returned with empty tags alongside the error.
+//
+// Use [Session.Inject] to also call the registered callbacks for a raw line.
+func ParseMessage(raw string) (m *IRCMessage, err error) {
 	if len(raw) == 0 {
-		return &IRCMessage{}
+		return &IRCMessage{}, nil
 	}
 
-	m := &IRCMessage{Raw: raw}
+	m = &IRCMessage{Raw: raw}
 
 	if raw[0] == '@' {
 		i := strings.Index(raw, " ")
-		m.Tags = ParseRawIRCTags(raw[1:i])
+		if i < 0 {
+			return nil, fmt.Errorf("%w: missing command after tags", ErrMalformedMessage)
+		}
+		m.Tags, err = parseRawIRCTags(raw[1:i])
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrMalformedMessage, err)
+		}
 		raw = raw[i+1:]
 	}
 
-	if raw[0] == ':' {
+	if len(raw) > 0 && raw[0] == ':' {
 		i := strings.Index(raw, " ")
-		source := strings.Split(raw[1:i], "!")
-		if len(source) == 2 {
-			m.Source = &IRCUser{Nickname: source[0], Host: source[0]}
+		if i < 0 {
+			return nil, fmt.Errorf("%w: missing command after source", ErrMalformedMessage)
+		}
+		// the source is either "<nick>!<user>@<host>" or only "<host>"
+		nick, userHost, isUser := strings.Cut(raw[1:i], "!")
+		if isUser {
+			user, host, _ := strings.Cut(userHost, "@")
+			m.Source = &IRCUser{Nickname: nick, Username: user, Host: host}
 		} else {
-			m.Source = &IRCUser{Host: source[0]}
+			m.Source = &IRCUser{Host: nick}
 		}
 		raw = raw[i+1:]
 	}
@@ -122,6 +261,9 @@ func parseMessage(raw string) *IRCMessage {
 	data := strings.Split(raw, " :")
 	args := strings.Split(data[0], " ")
 
+	if args[0] == "" {
+		return nil, fmt.Errorf("%w: missing command", ErrMalformedMessage)
+	}
 	m.Command.Name = IRCMessageCommandName(args[0])
 	if len(args) > 1 {
 		m.Command.Arguments = args[1:]
@@ -130,7 +272,7 @@ func parseMessage(raw string) *IRCMessage {
 		m.Command.Data = strings.Join(data[1:], " :")
 	}
 
-	return m
+	return m, err
 }
 
 func (m *IRCMessage) handle(s *Session) {
@@ -140,16 +282,27 @@ func (m *IRCMessage) handle(s *Session) {
 
 	// on ping commands only reply with a pong and exit the handler
 	if m.Command.Name == IRCMsgCmdPing {
-		s.SendCommand(string(IRCMsgCmdPong))
+		s.SendCommandf("%s :%s", IRCMsgCmdPong, m.Command.Data)
 		return
 	}
 
+	s.handleJoinConfirmation(m)
+	s.trackChannels(m)
+
+	s.callSafe(m, func() { s.dispatchHandler()(s, m) })
+}
+
+// dispatch calls all the registered callbacks for m.
+func dispatch(s *Session, m *IRCMessage) {
+	// dispatch is only reached for messages, which passed all middlewares
+	s.history.record(m)
+
 	handleCallback := ircCallbackEventMap[m.Command.Name]
 	if handleCallback == nil {
 		return
 	}
 	for _, c := range s.events[m.Command.Name] {
-		handleCallback(s, m, c)
+		s.callSafe(m, func() { handleCallback(s, m, c) })
 	}
 
 	handleCallback = ircCallbackEventMap["*"]
@@ -157,6 +310,18 @@ func (m *IRCMessage) handle(s *Session) {
 		return
 	}
 	for _, c := range s.events["*"] {
-		handleCallback(s, m, c)
+		s.callSafe(m, func() { handleCallback(s, m, c) })
 	}
 }
+
+// callSafe calls f and recovers from a panic in it, so a single failing callback does not stop
+// the bot. The panic is logged and passed to the callbacks registered with [Session.OnPanic].
+func (s *Session) callSafe(m *IRCMessage, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic while handling %s: %v\n%s", m.Command.Name, r, debug.Stack())
+			s.handlePanic(r, m)
+		}
+	}()
+	f()
+}
@@ -0,0 +1,150 @@
+package twitchgo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseMessageSource(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *IRCUser
+	}{
+		{
+			name: "user",
+			raw:  ":nick!user@host PRIVMSG #channel :hello",
+			want: &IRCUser{Nickname: "nick", Username: "user", Host: "host"},
+		},
+		{
+			name: "twitch user",
+			raw:  ":ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #channel :hello",
+			want: &IRCUser{Nickname: "ronni", Username: "ronni", Host: "ronni.tmi.twitch.tv"},
+		},
+		{
+			name: "server",
+			raw:  ":tmi.twitch.tv 001 bot :Welcome, GLHF!",
+			want: &IRCUser{Host: "tmi.twitch.tv"},
+		},
+		{
+			name: "user without host",
+			raw:  ":nick!user PRIVMSG #channel :hello",
+			want: &IRCUser{Nickname: "nick", Username: "user"},
+		},
+		{
+			name: "no source",
+			raw:  "PING :tmi.twitch.tv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMessage(tt.raw)
+			if err != nil {
+				t.Fatalf("ParseMessage() error = %v", err)
+			}
+			if tt.want == nil {
+				if m.Source != nil {
+					t.Errorf("Source = %+v, want nil", *m.Source)
+				}
+				return
+			}
+			if m.Source == nil {
+				t.Fatalf("Source = nil, want %+v", *tt.want)
+			}
+			if *m.Source != *tt.want {
+				t.Errorf("Source = %+v, want %+v", *m.Source, *tt.want)
+			}
+		})
+	}
+}
+
+func TestIRCUserPrefix(t *testing.T) {
+	for _, source := range []string{
+		"ronni!ronni@ronni.tmi.twitch.tv",
+		"nick!user@host",
+		"tmi.twitch.tv",
+	} {
+		m, err := ParseMessage(":" + source + " PRIVMSG #channel :hello")
+		if err != nil {
+			t.Fatalf("ParseMessage() error = %v", err)
+		}
+		if got := m.Source.Prefix(); got != source {
+			t.Errorf("Prefix() = %q, want %q", got, source)
+		}
+		if nick, _, isUser := strings.Cut(source, "!"); isUser && m.Source.String() != nick {
+			t.Errorf("String() = %q, want %q", m.Source.String(), nick)
+		}
+	}
+}
+
+// chunkReader returns the chunks one after another. A nil chunk returns os.ErrDeadlineExceeded.
+type chunkReader struct {
+	chunks [][]byte
+}
+
+func (r *chunkReader) Read(b []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	chunk := r.chunks[0]
+	if chunk == nil {
+		r.chunks = r.chunks[1:]
+		return 0, os.ErrDeadlineExceeded
+	}
+	n := copy(b, chunk)
+	if n < len(chunk) {
+		r.chunks[0] = chunk[n:]
+	} else {
+		r.chunks = r.chunks[1:]
+	}
+	return n, nil
+}
+
+func TestLineReaderPartialLine(t *testing.T) {
+	r := newLineReader(&chunkReader{chunks: [][]byte{
+		[]byte("PING :first\r\nPRIVMSG #channel :hel"),
+		nil,
+		[]byte("lo\r\n"),
+	}}, 16)
+
+	want := []string{"PING :first\r\n", "", "PRIVMSG #channel :hello\r\n"}
+	for i, w := range want {
+		buf, err := r.readAll()
+		if w == "" {
+			if err != os.ErrDeadlineExceeded {
+				t.Fatalf("read %d: error = %v, want %v", i, err, os.ErrDeadlineExceeded)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("read %d: error = %v", i, err)
+		}
+		if string(buf) != w {
+			t.Errorf("read %d = %q, want %q", i, buf, w)
+		}
+	}
+}
+
+func BenchmarkReadAll(b *testing.B) {
+	line := "@badge-info=;badges=;color=#FF0000;display-name=Foo;id=abc;user-id=1 :foo!foo@foo.tmi.twitch.tv PRIVMSG #bar :hello world\r\n"
+	stream := strings.Repeat(line, 10000)
+
+	// 1024 bytes is the size of the buffer used before the read buffer size was configurable
+	for _, size := range []int{1024, defaultReadBufferSize, 64 * 1024} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			b.SetBytes(int64(len(stream)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				r := newLineReader(strings.NewReader(stream), size)
+				for {
+					if _, err := r.readAll(); err != nil {
+						break
+					}
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // SendCommandf formats according to a format specifier and sends the resulting command to twitch
@@ -11,22 +13,22 @@ func (s *Session) SendCommandf(format string, a ...any) {
 	s.SendCommand(fmt.Sprintf(format, a...))
 }
 
-// SendCommand sends the given command to twitch
+// SendCommand sends the given command to twitch. It is safe to call SendCommand and the other
+// send methods from multiple goroutines. The commands are sent one after another and never
+// interleave.
 func (s *Session) SendCommand(cmd string) {
-	cmd = strings.TrimSuffix(cmd, "\n") + "\r\n"
-	if len(cmd) == 2 {
-		return
-	}
-	_, err := s.ircConn.Write([]byte(cmd))
-	if err != nil {
+	if err := s.sendCommand(cmd); err != nil {
 		log.Printf("failed to send command '%s': %+v", cmd, err)
-		return
 	}
-	if !strings.HasPrefix(cmd, string(IRCMsgCmdPass)) {
-		log.Printf("<< %s", cmd)
-	} else {
-		log.Printf("<< %s ***", IRCMsgCmdPass)
+}
+
+// sendCommand is like [Session.SendCommand], but returns the error instead of logging it.
+func (s *Session) sendCommand(cmd string) error {
+	cmd = strings.TrimSuffix(cmd, "\n") + "\r\n"
+	if len(cmd) == 2 {
+		return nil
 	}
+	return s.writer.write(cmd)
 }
 
 // SendMessagef formats according to a format specifier and sends the resulting message to the given
@@ -35,20 +37,182 @@ func (s *Session) SendMessagef(channel, format string, a ...any) {
 	s.SendMessage(channel, fmt.Sprintf(format, a...))
 }
 
-// SendMessage sends a message to the given channel
+// SendMessage sends a message to the given channel.
+//
+// Twitch truncates messages longer than 500 characters. If message splitting is enabled by
+// [Session.SetMessageSplitting], longer messages are sent as multiple messages instead.
 func (s *Session) SendMessage(channel, msg string) {
-	channel, _ = strings.CutPrefix(channel, "#")
-	s.SendCommandf("%s #%s :%s", IRCMsgCmdPrivmsg, channel, msg)
+	if err := s.sendMessage(channel, msg); err != nil {
+		log.Printf("failed to send message to '%s': %+v", channel, err)
+	}
+}
+
+// sendMessage is like [Session.SendMessage], but returns the error instead of logging it.
+func (s *Session) sendMessage(channel, msg string) error {
+	return s.sendReply(channel, "", msg)
+}
+
+// SendReply sends a message to the given channel as a reply to the message with the ID
+// parentMsgID. Twitch displays the message in the reply thread of the parent message. The ID of a
+// message is passed to the callbacks, e.g. msgID of [Session.OnChannelMessage].
+//
+// When the message is split by [Session.SetMessageSplitting], every part is sent as a reply.
+func (s *Session) SendReply(channel, parentMsgID, msg string) {
+	if err := s.sendReply(channel, parentMsgID, msg); err != nil {
+		log.Printf("failed to send reply to '%s': %+v", channel, err)
+	}
+}
+
+// sendReply is like [Session.SendReply], but returns the error instead of logging it. If
+// parentMsgID is empty, a normal message is sent.
+func (s *Session) sendReply(channel, parentMsgID, msg string) error {
+	channel = NormalizeChannel(channel)
+	msg, ok := s.filterOutbound(channel, msg)
+	if !ok {
+		return nil
+	}
+	var tags string
+	if parentMsgID != "" {
+		tags = fmt.Sprintf("@reply-parent-msg-id=%s ", EscapeTagValue(parentMsgID))
+	}
+	parts := []string{msg}
+	if s.splitMessages {
+		parts = splitMessage(msg, maxMessageLength)
+	}
+	for _, part := range parts {
+		err := s.sendCommand(fmt.Sprintf("%s%s #%s :%s", tags, IRCMsgCmdPrivmsg, channel, part))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BroadcastMessage sends msg to all the given channels, or to all joined channels if no channel is
+// given. The messages are sent one after another, like with [Session.SendMessage].
+//
+// The returned map contains the error for every channel the message could not be sent to. It is
+// empty if the message was sent to all channels.
+func (s *Session) BroadcastMessage(msg string, channels ...string) map[string]error {
+	if len(channels) == 0 {
+		channels = s.Channels()
+	}
+	errs := make(map[string]error)
+	for _, channel := range channels {
+		if err := s.sendMessage(channel, msg); err != nil {
+			errs[channel] = err
+		}
+	}
+	return errs
+}
+
+// SendAction sends an action message to the given channel. This is the same as sending
+// "/me <msg>" in the Twitch chat. Action messages are usually displayed in the color of the
+// sender's name.
+func (s *Session) SendAction(channel, msg string) {
+	channel = NormalizeChannel(channel)
+	msg, ok := s.filterOutbound(channel, msg)
+	if !ok {
+		return
+	}
+	parts := []string{msg}
+	if s.splitMessages {
+		parts = splitMessage(msg, maxMessageLength-len(actionPrefix)-len(actionSuffix))
+	}
+	for _, part := range parts {
+		s.SendCommandf("%s #%s :%s%s%s", IRCMsgCmdPrivmsg, channel, actionPrefix, part, actionSuffix)
+	}
+}
+
+// OutboundFilter is called with every chat message before it is sent. It returns the message to
+// send instead, which may be msg unchanged. Returning false drops the message.
+type OutboundFilter func(channel, msg string) (string, bool)
+
+// SetOutboundFilter sets the filter applied to every message sent by [Session.SendMessage],
+// [Session.SendReply], [Session.SendAction] and [Session.BroadcastMessage]. The channel is passed
+// without the leading "#". The filter is applied before the message is split, see
+// [Session.SetMessageSplitting]. Dropped messages are not reported as an error.
+//
+// This is useful to enforce a content policy, e.g. to remove blocked words before Twitch does.
+// Setting filter to nil disables filtering, which is the default.
+func (s *Session) SetOutboundFilter(filter OutboundFilter) *Session {
+	s.outboundFilter = filter
+	return s
+}
+
+// filterOutbound applies the outbound filter of s to msg, if set.
+func (s *Session) filterOutbound(channel, msg string) (string, bool) {
+	if s.outboundFilter == nil {
+		return msg, true
+	}
+	return s.outboundFilter(channel, msg)
+}
+
+// maxMessageLength is the maximum number of characters of a single chat message.
+const maxMessageLength = 500
+
+// SetMessageSplitting sets whether messages longer than the 500 characters allowed by Twitch are
+// split into multiple messages by [Session.SendMessage]. The messages are split on word boundaries
+// where possible. Splitting is disabled by default.
+func (s *Session) SetMessageSplitting(enabled bool) *Session {
+	s.splitMessages = enabled
+	return s
+}
+
+// splitMessage splits msg into parts of at most max characters. It splits at the last space within
+// the limit, or hard at the limit if a single word is too long.
+func splitMessage(msg string, max int) []string {
+	var parts []string
+	runes := []rune(strings.TrimSpace(msg))
+	for len(runes) > max {
+		cut := max
+		for i := max; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		parts = append(parts, string(runes))
+	}
+	return parts
 }
 
 // JoinChannel joins the given channel and receives messages from that channel afterwards
 func (s *Session) JoinChannel(channel string) {
-	channel, _ = strings.CutPrefix(channel, "#")
-	s.SendCommandf("%s #%s", IRCMsgCmdJoin, channel)
+	s.JoinChannels(channel)
+}
+
+// joinRateLimit is the number of channels a bot may join within joinRateLimitWindow.
+const (
+	joinRateLimit       = 20
+	joinRateLimitWindow = 10 * time.Second
+)
+
+// JoinChannels joins all the given channels and receives messages from those channels afterwards.
+// The channels are joined with as few commands as possible.
+//
+// Twitch allows to join 20 channels every 10 seconds. When joining more than 20 channels,
+// JoinChannels blocks and waits between every 20 channels to respect that limit.
+func (s *Session) JoinChannels(channels ...string) {
+	for i, batch := range chunk(channels, joinRateLimit) {
+		if i > 0 {
+			time.Sleep(joinRateLimitWindow)
+		}
+		names := make([]string, len(batch))
+		for i, channel := range batch {
+			names[i] = "#" + NormalizeChannel(channel)
+		}
+		s.SendCommandf("%s %s", IRCMsgCmdJoin, strings.Join(names, ","))
+	}
 }
 
 // LeaveChannel leaves the given channel and nolonger receives messages from that channel afterwards
 func (s *Session) LeaveChannel(channel string) {
-	channel, _ = strings.CutPrefix(channel, "#")
+	channel = NormalizeChannel(channel)
+	s.channels.markLeaving(channel)
 	s.SendCommandf("%s #%s", IRCMsgCmdPart, channel)
 }
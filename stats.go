@@ -0,0 +1,71 @@
+package twitchgo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SessionStats is a snapshot of the counters of a Session, see [Session.Stats]. All counters start
+// at zero when the Session is created.
+type SessionStats struct {
+	// The number of messages received from the Twitch IRC server by their command, e.g. PRIVMSG.
+	MessagesReceived map[IRCMessageCommandName]uint64
+	// The number of lines received from the Twitch IRC server, which could not be parsed.
+	ParseErrors uint64
+	// The number of commands sent to the Twitch IRC server.
+	MessagesSent uint64
+	// The number of commands which could not be sent to the Twitch IRC server.
+	SendFailures uint64
+	// The number of times the Session connected to the Twitch IRC server again after the first
+	// connection.
+	Reconnects uint64
+
+	// The number of requests sent to the Twitch API, including retries.
+	APICalls uint64
+	// The number of requests to the Twitch API, which failed or responded with a status code other
+	// than 2xx.
+	APIErrors uint64
+}
+
+// sessionStats holds the counters of a Session. The counters are updated atomically, so counting
+// does not need to lock the Session.
+type sessionStats struct {
+	// received maps IRCMessageCommandName to *atomic.Uint64
+	received     sync.Map
+	parseErrors  atomic.Uint64
+	sent         atomic.Uint64
+	sendFailures atomic.Uint64
+	connects     atomic.Uint64
+	apiCalls     atomic.Uint64
+	apiErrors    atomic.Uint64
+}
+
+// countReceived increments the counter of received messages with the given command.
+func (st *sessionStats) countReceived(cmd IRCMessageCommandName) {
+	counter, ok := st.received.Load(cmd)
+	if !ok {
+		counter, _ = st.received.LoadOrStore(cmd, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+}
+
+// Stats returns a snapshot of the counters of s. Use it to monitor the throughput and error rates
+// of the bot.
+func (s *Session) Stats() SessionStats {
+	stats := SessionStats{
+		MessagesReceived: make(map[IRCMessageCommandName]uint64),
+		ParseErrors:      s.stats.parseErrors.Load(),
+		MessagesSent:     s.stats.sent.Load(),
+		SendFailures:     s.stats.sendFailures.Load(),
+		APICalls:         s.stats.apiCalls.Load(),
+		APIErrors:        s.stats.apiErrors.Load(),
+	}
+	if connects := s.stats.connects.Load(); connects > 1 {
+		stats.Reconnects = connects - 1
+	}
+	s.stats.received.Range(func(cmd, counter any) bool {
+		stats.MessagesReceived[cmd.(IRCMessageCommandName)] = counter.(*atomic.Uint64).Load()
+		return true
+	})
+	return stats
+}
@@ -0,0 +1,39 @@
+package twitchgo
+
+// SubPlan is the type of subscription plan of a sub, resub or subgift notice, see
+// [IRCMessageTags.SubPlan].
+type SubPlan string
+
+const (
+	// SubPlanPrime is an Amazon Prime subscription.
+	SubPlanPrime SubPlan = "Prime"
+	// SubPlanTier1 is the first level of paid subscription.
+	SubPlanTier1 SubPlan = "1000"
+	// SubPlanTier2 is the second level of paid subscription.
+	SubPlanTier2 SubPlan = "2000"
+	// SubPlanTier3 is the third level of paid subscription.
+	SubPlanTier3 SubPlan = "3000"
+)
+
+// String returns a human readable label of the plan, e.g. "Tier 1" for [SubPlanTier1]. Unknown
+// plans are returned as is.
+func (p SubPlan) String() string {
+	switch p {
+	case SubPlanPrime:
+		return "Prime"
+	case SubPlanTier1:
+		return "Tier 1"
+	case SubPlanTier2:
+		return "Tier 2"
+	case SubPlanTier3:
+		return "Tier 3"
+	default:
+		return string(p)
+	}
+}
+
+// SubPlan returns the subscription plan of a sub, resub or subgift notice. It is empty for other
+// messages.
+func (t IRCMessageTags) SubPlan() SubPlan {
+	return SubPlan(t.MsgParamSubPlan)
+}
@@ -1,12 +1,17 @@
 package twitchgo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"net"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/kesuaheli/twitchgo/oauth"
 )
 
@@ -24,22 +29,69 @@ var (
 	// ErrInvalidToken is returned when the provided token or username is invalid or improperly
 	// formatted and a connection could not be established.
 	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrCloseTimeout is returned by [Session.Close], when the IRC server did not close the
+	// connection in time.
+	ErrCloseTimeout = errors.New("timed out waiting for the connection to close")
 )
 
 // Session is the instance for all Twitch events.
 type Session struct {
 	mu sync.Mutex
 
-	clientID      string
-	clientSecret  string
-	webhookSecret string
-	oauth         *oauth.Client
+	clientID       string
+	clientSecret   string
+	webhookSecret  string
+	oauth          *oauth.Client
+	rateLimit      rateLimit
+	apiMaxAttempts int
+	apiRetryDelay  time.Duration
+	userIDs        userIDCache
+	users          userCache
+
+	eventSubConn      *websocket.Conn
+	eventSubSessionID string
+	eventSubEvents    map[SubscriptionType][]interface{}
+	eventSubMu        sync.Mutex
+
+	eventSubMessageIDs eventSubMessageIDs
+
+	ircToken     string
+	capabilities []string
+	ircConn      net.Conn
+	ircReader    *lineReader
+	readBufSize  int
+	writer       commandWriter
+	// listenDone is closed when the listener goroutine exits
+	listenDone chan struct{}
+	// quitSent is set by [Session.Close] before sending QUIT, so the listener treats the closed
+	// connection as a clean exit
+	quitSent    atomic.Bool
+	login       string
+	self        *IRCMessageTags
+	stateMu     sync.Mutex
+	events      map[IRCMessageCommandName][]interface{}
+	eventMu     sync.Mutex
+	middlewares []Middleware
+	Prefix      string
+
+	prefixes        []string
+	mentionCommands bool
+	prefixMu        sync.Mutex
+	channelPrefixes channelPrefixes
+
+	rawLogger RawLogger
+	// closed is set when the connection was closed by [Session.Close]. It prevents reconnecting.
+	closed            bool
+	keepAliveInterval time.Duration
+	keepAliveTimeout  time.Duration
 
-	ircToken string
-	ircConn  *net.TCPConn
-	events   map[IRCMessageCommandName][]interface{}
-	eventMu  sync.Mutex
-	Prefix   string
+	splitMessages  bool
+	outboundFilter OutboundFilter
+	joinWaiters    joinWaiters
+	channels       joinedChannels
+	history        messageHistory
+	stats          sessionStats
 }
 
 // New creates a new Twitch instance for API and IRC connections. Can be used to register event
@@ -95,10 +147,34 @@ func (s *Session) SetAPI(clientID, clientSecret string) *Session {
 		clientSecret,
 		"",
 	)
+	s.initEventSubEvents()
+	s.apiMaxAttempts = 3
+	s.apiRetryDelay = 500 * time.Millisecond
 
 	return s
 }
 
+// initEventSubEvents creates the map of the EventSub callbacks, if not done yet. EventSub
+// notifications can also be received without API credentials, e.g. by [Session.EventSubHandler].
+func (s *Session) initEventSubEvents() {
+	if s.eventSubEvents == nil {
+		s.eventSubEvents = make(map[SubscriptionType][]interface{})
+	}
+}
+
+// SetAPIRetries sets how API requests failing with a transient error (status code 429, 500, 502,
+// 503 or 504) are retried. The request is attempted up to maxAttempts times in total, waiting
+// delay before the first retry and doubling the delay for every further retry. If Twitch sends a
+// Retry-After header, its value is used instead.
+//
+// By default, requests are attempted 3 times with an initial delay of 500ms. Setting maxAttempts
+// to 1 disables retries.
+func (s *Session) SetAPIRetries(maxAttempts int, delay time.Duration) *Session {
+	s.apiMaxAttempts = maxAttempts
+	s.apiRetryDelay = delay
+	return s
+}
+
 // SetAuthRefreshToken sets a custom refresh token to use for the API calls.
 func (s *Session) SetAuthRefreshToken(refreshToken string) *Session {
 	if s.oauth == nil {
@@ -108,6 +184,70 @@ func (s *Session) SetAuthRefreshToken(refreshToken string) *Session {
 	return s
 }
 
+// AuthRefreshToken returns the current refresh token used for the API calls. It may differ from the
+// one set by [Session.SetAuthRefreshToken], because Twitch can rotate refresh tokens.
+func (s *Session) AuthRefreshToken() string {
+	if s.oauth == nil {
+		panic("Session has no API auth")
+	}
+	return s.oauth.RefreshToken()
+}
+
+// SetRedirectURI sets the redirect URI used by the authorization code flow to get a user access
+// token. It has to match one of the redirect URIs registered for the application.
+func (s *Session) SetRedirectURI(redirectURI string) *Session {
+	if s.oauth == nil {
+		panic("Session has no API auth")
+	}
+	s.oauth.RedirectURI = redirectURI
+	return s
+}
+
+// AuthCodeURL returns the URL to the consent page, where the user authorizes the application with
+// the given scopes. After authorizing, the user is redirected to the redirect URI set by
+// [Session.SetRedirectURI] with the query parameters "code" and "state". Pass the code to
+// [Session.ExchangeCode] to use the access token of the user for the API calls.
+//
+// state should be a random string, which is then used to verify that the redirect belongs to this
+// request, see [Session.WaitForCode].
+func (s *Session) AuthCodeURL(state string, scopes ...string) string {
+	if s.oauth == nil {
+		panic("Session has no API auth")
+	}
+	return s.oauth.AuthCodeURL("", state, scopes)
+}
+
+// ExchangeCode exchanges the authorization code, received by the redirect after the user
+// authorized the application, for a user access token. All following API calls use the token of
+// the user, which is refreshed automatically. Persist [Session.AuthRefreshToken] to skip the
+// authorization next time.
+//
+// The redirect URI has to be set by [Session.SetRedirectURI], otherwise
+// [oauth.ErrMissingRedirectURI] is returned.
+func (s *Session) ExchangeCode(code string) error {
+	if s.oauth == nil {
+		panic("Session has no API auth")
+	}
+	if _, err := s.oauth.ExchangeCode(code, ""); err != nil {
+		return fmt.Errorf("exchange code: %w", err)
+	}
+	return nil
+}
+
+// WaitForCode starts a local HTTP server listening on addr (e.g. "localhost:3000") and waits for
+// the redirect after the user authorized the application on the page of [Session.AuthCodeURL]. The
+// redirect URI set by [Session.SetRedirectURI] has to point to this server. It returns the
+// authorization code, which can then be passed to [Session.ExchangeCode].
+//
+// The state query parameter of the redirect has to match state. WaitForCode returns when the
+// redirect was received or ctx is done.
+func (s *Session) WaitForCode(ctx context.Context, addr, state string) (string, error) {
+	if s.oauth == nil {
+		panic("Session has no API auth")
+	}
+	return s.oauth.WaitForCode(ctx, addr, state)
+}
+
 // SetIRC sets the token used for a connection to the Twitch IRC server. It will override the
 // existing token, if previously set. Setting ircToken to an empty string will result in not
 // connecting to the IRC server on the call to s.Connect. SetIRC will panic when s.Connect was
@@ -121,59 +261,174 @@ func (s *Session) SetIRC(ircToken string) *Session {
 
 	s.ircToken = ircToken
 	s.events = make(map[IRCMessageCommandName][]interface{})
+	s.initEventSubEvents()
 	s.Prefix = "!"
+	s.rawLogger = defaultRawLogger
+	s.capabilities = []string{CapCommands, CapMembership, CapTags}
 	return s
 }
 
-// SetWebhookSecret sets the secret used for verifying webhook requests. It will override the
-// existing secret, if previously set.
+// Capabilities of the Twitch IRC server, see [Session.SetCapabilities].
+const (
+	// CapCommands enables Twitch specific commands like NOTICE, USERNOTICE and GLOBALUSERSTATE. It
+	// is always requested, because the Session needs it to connect.
+	CapCommands = "twitch.tv/commands"
+	// CapMembership enables JOIN and PART messages of other users.
+	CapMembership = "twitch.tv/membership"
+	// CapTags adds tags to messages, like the user ID and badges of the sender.
+	CapTags = "twitch.tv/tags"
+)
+
+// SetCapabilities sets the capabilities requested from the Twitch IRC server on the call to
+// s.Connect. By default, [CapCommands], [CapMembership] and [CapTags] are requested.
+//
+// In large channels, the JOIN and PART messages enabled by [CapMembership] are very noisy, so
+// leave it out if [Session.OnChannelJoin] and [Session.OnChannelLeave] are not needed for other
+// users. [CapCommands] is always requested, even if not given.
+func (s *Session) SetCapabilities(caps ...string) *Session {
+	s.capabilities = []string{CapCommands}
+	for _, c := range caps {
+		if c != CapCommands {
+			s.capabilities = append(s.capabilities, c)
+		}
+	}
+	return s
+}
+
+// SetWebhookSecret sets the secret used for creating webhook subscriptions and verifying webhook
+// requests. It will override the existing secret, if previously set. Twitch requires the secret to
+// be between 10 and 100 characters long, SetWebhookSecret will panic otherwise.
 func (s *Session) SetWebhookSecret(secret string) *Session {
+	if len(secret) < 10 || len(secret) > 100 {
+		panic("webhook secret must be between 10 and 100 characters long")
+	}
 	s.webhookSecret = secret
 	return s
 }
 
 // Connect actually starts the connection to the Twitch IRC server.
+//
+// A Session can be connected again after it was closed with [Session.Close]. The registered
+// callbacks are kept, but channels have to be joined again.
 func (s *Session) Connect() (err error) {
 	if s.ircToken == "" {
 		return nil
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = false
+	return s.connect()
+}
+
+// connect connects to the Twitch IRC server. The caller has to hold s.mu.
+func (s *Session) connect() (err error) {
 	if s.ircConn != nil {
 		return ErrAlreadyConnected
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	address := fmt.Sprintf("%s:%d", IRCHost, IRCPort)
 	raddr, err := net.ResolveTCPAddr("tcp", address)
 	if err != nil {
 		return err
 	}
 	log.Printf("Connecting to %v", raddr)
-	s.ircConn, err = net.DialTCP("tcp", nil, raddr)
+	conn, err := net.DialTCP("tcp", nil, raddr)
 	if err != nil {
 		log.Printf("Dial failed: %+v", err)
 		return err
 	}
+	s.ircConn = conn
+	s.ircReader = newLineReader(conn, s.readBufferSize())
+	s.writer.start(s, conn)
 
-	s.SendCommand("CAP REQ :twitch.tv/commands twitch.tv/membership twitch.tv/tags")
+	s.SendCommandf("%s REQ :%s", IRCMsgCmdCap, strings.Join(s.capabilities, " "))
 	s.SendCommandf("PASS %s", s.ircToken)
 	s.SendCommand("NICK -")
 
 	if err = waitForInit(s); err != nil {
 		s.ircConn.Close()
+		s.reset()
 		return err
 	}
 
+	s.stats.connects.Add(1)
+	s.quitSent.Store(false)
+	s.listenDone = make(chan struct{})
 	go listen(s)
 	return nil
 }
 
-// Close closes the connection to the Twitch IRC server.
-func (s *Session) Close() {
-	if s.ircConn != nil {
-		s.ircConn.Close()
+// Self returns the identity of the bot user, as sent by Twitch after connecting. The tags include
+// the display name, user ID, color, badges and emote sets of the bot. Login is set to the login
+// name of the bot.
+//
+// Self returns nil, if the bot is not connected to the IRC server.
+func (s *Session) Self() *IRCMessageTags {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	if s.self == nil {
+		return nil
+	}
+	self := *s.self
+	return &self
+}
+
+// closeTimeout is the time [Session.Close] waits for the IRC server to close the connection.
+const closeTimeout = 5 * time.Second
+
+// Close gracefully closes the connection to the Twitch IRC server and the EventSub WebSocket server.
+// It sends a QUIT command and waits up to 5 seconds for the server to close the connection and for
+// all pending messages to be handled. If the server does not close the connection in time, the
+// connection is closed anyway and [ErrCloseTimeout] is returned.
+//
+// Close resets the connection state of the Session, so it can be connected again with
+// [Session.Connect]. Callers still waiting in [Session.JoinChannelAndWait] return [net.ErrClosed].
+//
+// Close can be called from a callback, e.g. of a shutdown command. Because the pending messages
+// are handled by the same goroutine, Close can't wait for them then and returns [ErrCloseTimeout]
+// after the timeout.
+func (s *Session) Close() (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.closeEventSub()
+	if s.ircConn == nil {
+		return nil
+	}
+
+	s.quitSent.Store(true)
+	s.SendCommand(string(IRCMsgCmdQuit))
+	select {
+	case <-s.listenDone:
+	case <-time.After(closeTimeout):
+		err = ErrCloseTimeout
+	}
+	s.ircConn.Close()
+	// don't wait forever, the listener does not return while Close is called from one of its
+	// callbacks
+	select {
+	case <-s.listenDone:
+	case <-time.After(closeTimeout):
 	}
+	s.reset()
+
 	log.Print("Twitch connection closed!")
+	return err
+}
+
+// reset clears the state of the IRC connection, so the Session can be connected again.
+func (s *Session) reset() {
+	s.writer.halt()
+	s.ircConn = nil
+	s.ircReader = nil
+	s.login = ""
+	s.stateMu.Lock()
+	s.self = nil
+	s.stateMu.Unlock()
+	s.joinWaiters.resolveAll(net.ErrClosed)
+	s.channels.clear()
 }
@@ -0,0 +1,47 @@
+package twitchgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSessionAuthCodeFlow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "code123" || r.Form.Get("redirect_uri") != "http://localhost:3000" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"user-token","refresh_token":"refresh","expires_in":3600,"scope":["chat:read"]}`))
+	}))
+	defer server.Close()
+
+	s := NewAPIOnly("id", "secret").SetRedirectURI("http://localhost:3000")
+	s.oauth.RequestURL = server.URL + "/oauth2/token"
+
+	authURL, err := url.Parse(s.AuthCodeURL("state", "chat:read", "chat:edit"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL() is no valid URL: %v", err)
+	}
+	if !strings.HasSuffix(authURL.Path, "/oauth2/authorize") {
+		t.Errorf("AuthCodeURL() path = %q, want the authorize endpoint", authURL.Path)
+	}
+	query := authURL.Query()
+	if query.Get("redirect_uri") != "http://localhost:3000" || query.Get("state") != "state" || query.Get("scope") != "chat:read chat:edit" {
+		t.Errorf("AuthCodeURL() query = %v", query)
+	}
+
+	if err = s.ExchangeCode("code123"); err != nil {
+		t.Fatalf("ExchangeCode() error = %v", err)
+	}
+	if got := s.AuthRefreshToken(); got != "refresh" {
+		t.Errorf("AuthRefreshToken() = %q, want %q", got, "refresh")
+	}
+	if token, _ := s.oauth.GenerateToken(); token != "user-token" {
+		t.Errorf("session token = %q, want %q", token, "user-token")
+	}
+}